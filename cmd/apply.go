@@ -0,0 +1,176 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/manifest"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+var (
+	manifestFile string
+	dryRun       bool
+
+	applyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Converge the target directory with a reposync.yaml manifest",
+		Long: `Converge the target directory with a reposync.yaml manifest.
+Repositories listed in the manifest but missing from the target directory are
+cloned, existing ones are fast-forwarded, and repositories in the target
+directory that the manifest doesn't list are reported but left untouched.`,
+		Args: cobra.NoArgs,
+		RunE: runApply,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringVarP(&manifestFile, "file", "f", manifest.DefaultFile, "Path to the manifest")
+	applyCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the plan without changing anything")
+	addCloneFlags(applyCmd)
+}
+
+// runApply handles the apply subcommand.
+func runApply(cmd *cobra.Command, args []string) error {
+	m, err := manifest.Load(manifestFile)
+	if err != nil {
+		return err
+	}
+
+	// Explicit flags win over the manifest, which wins over the environment
+	// and persisted settings
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+	if m.TargetDir != "" {
+		settings.TargetDir = m.TargetDir
+	}
+	if m.Layout != "" && !cmd.Flags().Changed("layout") {
+		settings.Layout = m.Layout
+	}
+	if m.Protocol != "" && !cmd.Flags().Changed("protocol") {
+		settings.Protocol = m.Protocol
+	}
+
+	repoLayout, err := settings.GetLayout()
+	if err != nil {
+		return err
+	}
+	protocol, err := github.ParseProtocol(settings.Protocol)
+	if err != nil {
+		return err
+	}
+
+	targetDir := settings.TargetDir
+	if !dryRun {
+		if targetDir, err = settings.GetTargetDir(); err != nil {
+			return fmt.Errorf("failed to get target directory: %w", err)
+		}
+	}
+
+	// One client per owner, shared between owners on the same host
+	clients := make(map[string]*github.Client)
+	clientFor := func(owner manifest.Owner) (*github.Client, error) {
+		host := owner.Host
+		if host == "" {
+			host = settings.HostForOwner(owner.Name)
+		}
+		if client, ok := clients[host]; ok {
+			return client, nil
+		}
+		client, err := github.NewClientForHost(host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize GitHub client: %w", err)
+		}
+		client.SetProtocol(protocol)
+		client.SetLayout(repoLayout)
+		clients[host] = client
+		return client, nil
+	}
+
+	ownerClients := make(map[string]*github.Client)
+	plan, err := manifest.BuildPlan(m, targetDir, func(owner manifest.Owner) (manifest.Source, error) {
+		client, err := clientFor(owner)
+		if err != nil {
+			return nil, err
+		}
+		ownerClients[owner.Key()] = client
+		return client, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	plan.Print(os.Stdout)
+	if dryRun {
+		return nil
+	}
+
+	var (
+		mu       sync.Mutex
+		failures int
+	)
+	report := func(verb, done string) func(event workerpool.Event) {
+		return func(event workerpool.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch event.Status {
+			case workerpool.StatusFailed:
+				failures++
+				fmt.Fprintf(os.Stderr, "Error %s %s: %v\n", verb, event.Name, event.Err)
+			case workerpool.StatusSucceeded:
+				fmt.Printf("Successfully %s %s\n", done, event.Name)
+			}
+		}
+	}
+
+	// Clone missing repositories, grouped by owner so each uses its client.
+	// Owners are keyed by host and name, as the same name may be listed for
+	// several hosts.
+	reposByOwner := make(map[string][]github.Repository)
+	for _, step := range plan.Filter(manifest.ActionClone) {
+		reposByOwner[step.OwnerKey()] = append(reposByOwner[step.OwnerKey()], step.Repo)
+	}
+	for _, owner := range m.Owners {
+		repos := reposByOwner[owner.Key()]
+		if len(repos) == 0 {
+			continue
+		}
+		// settings.Clone only holds the clone flags given on the command line
+		optsFn := func(id string) clone.Options {
+			return settings.CloneOptionsFor(id).Merge(m.CloneOptionsFor(owner, path.Base(id))).Merge(settings.Clone)
+		}
+		ownerClients[owner.Key()].CloneRepos(repos, targetDir, settings.GetJobs(), optsFn, report("cloning", "cloned"))
+	}
+
+	// Fast-forward repositories that are already present
+	updates := plan.Filter(manifest.ActionUpdate)
+	progressFn := report("updating", "updated")
+	workerpool.Run(settings.GetJobs(), len(updates), func(i int) {
+		step := updates[i]
+		progressFn(workerpool.Finished(i, step.Repo.FullName, ownerClients[step.OwnerKey()].FastForwardRepo(step.Path)))
+	})
+
+	return batchError("apply", failures, len(plan.Filter(manifest.ActionClone))+len(updates))
+}
@@ -0,0 +1,130 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/report"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+// Ways to handle repositories that already exist in batch mode.
+const (
+	existingSkip    = "skip"
+	existingRefresh = "refresh"
+)
+
+var (
+	outputFormat string
+	existingMode string
+
+	// Clone options for the batch
+	cloneDepth        int
+	cloneFilter       string
+	cloneSingleBranch bool
+	cloneSparse       []string
+)
+
+// addBatchFlags registers the flags shared by the batch modes of the github
+// and local subcommands.
+func addBatchFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", string(report.FormatText), "Batch output format: text, table, json, or ndjson")
+	cmd.Flags().StringVar(&existingMode, "existing", existingSkip, "What to do with repositories that already exist: skip or refresh (git pull)")
+}
+
+// addCloneFlags registers the clone option flags of the subcommands that
+// clone or copy repositories.
+func addCloneFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&cloneDepth, "depth", 0, "Create shallow clones with this many commits")
+	cmd.Flags().StringVar(&cloneFilter, "filter", "", "Partial clone filter, e.g. blob:none")
+	cmd.Flags().BoolVar(&cloneSingleBranch, "single-branch", false, "Only clone the default branch")
+	cmd.Flags().StringSliceVar(&cloneSparse, "sparse", nil, "Comma-separated directories for a sparse checkout")
+}
+
+// parseBatchFlags validates the shared batch flags.
+func parseBatchFlags() (report.Format, error) {
+	format, err := report.ParseFormat(outputFormat)
+	if err != nil {
+		return "", err
+	}
+	if existingMode != existingSkip && existingMode != existingRefresh {
+		return "", fmt.Errorf("invalid --existing value %q: must be skip or refresh", existingMode)
+	}
+	return format, nil
+}
+
+// batchTarget is a repository handled by a batch command.
+type batchTarget struct {
+	name string // Name used in the report
+	path string // Destination inside the target directory
+}
+
+// runBatch clones the targets missing from the target directory and skips or
+// refreshes the ones that already exist, adding a record per target to out.
+// cloneFn receives the indices of the targets to clone and must report
+// progress with event indices into that slice.
+func runBatch(targets []batchTarget, jobs int, out *report.Writer,
+	cloneFn func(indices []int, progressFn func(event workerpool.Event)),
+	refreshFn func(repoPath string) error,
+) {
+	var missing, existing []int
+	for i, target := range targets {
+		if _, err := os.Stat(target.path); err == nil {
+			existing = append(existing, i)
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	// Events for one index come from a single worker, so no locking is needed
+	starts := make([]time.Time, len(missing))
+	cloneFn(missing, func(event workerpool.Event) {
+		target := targets[missing[event.Index]]
+		switch event.Status {
+		case workerpool.StatusStarted:
+			starts[event.Index] = time.Now()
+			out.Started(target.name)
+		default:
+			record := report.Record{Name: target.name, Action: report.ActionCloned, Path: target.path, Detail: event.Detail, Duration: time.Since(starts[event.Index])}
+			if event.Err != nil {
+				record.Action, record.Err = report.ActionFailed, event.Err
+			}
+			out.Add(record)
+		}
+	})
+
+	if existingMode == existingSkip {
+		for _, i := range existing {
+			out.Add(report.Record{Name: targets[i].name, Action: report.ActionSkipped, Path: targets[i].path})
+		}
+		return
+	}
+
+	workerpool.Run(jobs, len(existing), func(n int) {
+		target := targets[existing[n]]
+		start := time.Now()
+		record := report.Record{Name: target.name, Action: report.ActionRefreshed, Path: target.path}
+		if err := refreshFn(target.path); err != nil {
+			record.Action, record.Err = report.ActionFailed, err
+		}
+		record.Duration = time.Since(start)
+		out.Add(record)
+	})
+}
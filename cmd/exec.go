@@ -0,0 +1,206 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/report"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+var (
+	execDirs   []string
+	execMatch  []string
+	execOutput string
+
+	execCmd = &cobra.Command{
+		Use:   "exec [flags] -- <command> [args...]",
+		Short: "Run a command in every repository in the workspace",
+		Long: `Run a command in every repository in the workspace.
+Without --dir, the target directory and the configured source directories are
+scanned. The command runs with each repository as its working directory, at
+most --jobs at a time. Its output is captured per repository and printed as a
+block once the command finishes there, followed by a pass/fail summary.
+The command is run directly; use "sh -c '...'" for pipes and other shell
+syntax.`,
+		Example: `  reposync exec -- git status --short
+  reposync exec --match 'api-*' -- make test
+  reposync exec -o json -- sh -c 'git log -1 --format=%h'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runExec,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	// Flags after the command belong to the command
+	execCmd.Flags().SetInterspersed(false)
+	execCmd.Flags().StringSliceVar(&execDirs, "dir", nil, "Directory to search for repositories (repeatable)")
+	execCmd.Flags().StringSliceVar(&execMatch, "match", nil, "Only run in repositories whose name matches a glob (repeatable)")
+	execCmd.Flags().StringVarP(&execOutput, "output", "o", string(report.FormatText), "Output format: text, json, or ndjson")
+}
+
+// execRecord is the machine-readable form of an exec result.
+type execRecord struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	Error      string `json:"error,omitempty"`
+}
+
+// runExec handles the exec subcommand.
+func runExec(cmd *cobra.Command, args []string) error {
+	format, err := report.ParseFormat(execOutput)
+	if err != nil {
+		return err
+	}
+	if format == report.FormatTable {
+		return fmt.Errorf("invalid output format %q: must be text, json, or ndjson", execOutput)
+	}
+	for _, pattern := range execMatch {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --match pattern %q: %w", pattern, err)
+		}
+	}
+	cmd.SilenceUsage = true
+
+	repos, err := findWorkspaceRepos(execDirs)
+	if err != nil {
+		return err
+	}
+	repos = matchRepos(repos, execMatch)
+	if len(repos) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	// The worker count falls back to the persisted settings
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+
+	scanner := local.NewScanner()
+	results := make([]local.ExecResult, len(repos))
+	var mu sync.Mutex
+	workerpool.Run(settings.GetJobs(), len(repos), func(i int) {
+		result := scanner.ExecRepo(repos[i], args)
+
+		mu.Lock()
+		defer mu.Unlock()
+		results[i] = result
+		switch format {
+		case report.FormatText:
+			printExecResult(result)
+		case report.FormatNDJSON:
+			data, _ := json.Marshal(newExecRecord(result))
+			fmt.Println(string(data))
+		}
+	})
+
+	var failed []string
+	for _, result := range results {
+		if !result.Passed() {
+			failed = append(failed, result.Name)
+		}
+	}
+	sort.Strings(failed)
+
+	switch format {
+	case report.FormatText:
+		fmt.Printf("\n%d passed, %d failed\n", len(results)-len(failed), len(failed))
+		if len(failed) > 0 {
+			fmt.Printf("Failed: %s\n", strings.Join(failed, ", "))
+		}
+	case report.FormatJSON:
+		sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+		records := make([]execRecord, len(results))
+		for i, result := range results {
+			records[i] = newExecRecord(result)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(records); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	return batchError("exec", len(failed), len(results))
+}
+
+// matchRepos returns the repositories whose name matches one of patterns, or
+// all of them when there are no patterns.
+func matchRepos(repos []local.Repository, patterns []string) []local.Repository {
+	if len(patterns) == 0 {
+		return repos
+	}
+
+	var matched []local.Repository
+	for _, repo := range repos {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, repo.Name); ok {
+				matched = append(matched, repo)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// printExecResult writes a result's header and captured output as one block.
+func printExecResult(result local.ExecResult) {
+	status := fmt.Sprintf("exit %d", result.ExitCode)
+	if result.ExitCode < 0 {
+		status = result.Err.Error()
+	}
+	fmt.Printf("==> %s (%s, %s)\n", result.Name, status, result.Duration.Round(time.Millisecond))
+	fmt.Print(withNewline(result.Stdout))
+	fmt.Fprint(os.Stderr, withNewline(result.Stderr))
+}
+
+// withNewline terminates non-empty output with a newline.
+func withNewline(s string) string {
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		return s + "\n"
+	}
+	return s
+}
+
+// newExecRecord converts a result into its output record.
+func newExecRecord(result local.ExecResult) execRecord {
+	record := execRecord{
+		Name:       result.Name,
+		Path:       result.Path,
+		ExitCode:   result.ExitCode,
+		DurationMS: result.Duration.Milliseconds(),
+		Stdout:     result.Stdout,
+		Stderr:     result.Stderr,
+	}
+	if result.Err != nil {
+		record.Error = result.Err.Error()
+	}
+	return record
+}
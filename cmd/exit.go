@@ -0,0 +1,69 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes returned by reposync.
+const (
+	// ExitFailure means the command failed or every repository failed.
+	ExitFailure = 1
+	// ExitPartialFailure means some, but not all, repositories failed.
+	ExitPartialFailure = 2
+)
+
+// ExitError is an error with a specific process exit code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error returns the underlying error message.
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
+
+// batchError returns the error for a batch in which failed of total
+// repositories failed, or nil if none did.
+func batchError(verb string, failed, total int) error {
+	switch {
+	case failed == 0:
+		return nil
+	case failed == total:
+		return &ExitError{Code: ExitFailure, Err: fmt.Errorf("%s failed for all %d repositories", verb, total)}
+	default:
+		return &ExitError{Code: ExitPartialFailure, Err: fmt.Errorf("%s failed for %d of %d repositories", verb, failed, total)}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/lockfile"
+)
+
+var (
+	lockFile string
+
+	freezeCmd = &cobra.Command{
+		Use:   "freeze",
+		Short: "Write a lockfile pinning every repository in the target directory",
+		Long: `Write a lockfile pinning every repository in the target directory.
+For each repository the lockfile records its path, origin remote URL, branch
+and HEAD commit, so the workspace can be recreated with 'reposync restore'.`,
+		Args: cobra.NoArgs,
+		RunE: runFreeze,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(freezeCmd)
+
+	freezeCmd.Flags().StringVarP(&lockFile, "file", "f", lockfile.DefaultFile, "Path to the lockfile")
+}
+
+// runFreeze handles the freeze subcommand.
+func runFreeze(cmd *cobra.Command, args []string) error {
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+	targetDir, err := settings.GetTargetDir()
+	if err != nil {
+		return fmt.Errorf("failed to get target directory: %w", err)
+	}
+
+	lock, err := lockfile.Freeze(local.NewScanner(), targetDir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range lock.Repos {
+		if entry.Remote == "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s has no origin remote and cannot be cloned on restore\n", entry.Path)
+		}
+		if entry.Dirty {
+			fmt.Fprintf(os.Stderr, "Warning: %s has uncommitted changes that are not recorded\n", entry.Path)
+		}
+	}
+
+	if err := lock.Save(lockFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Froze %d repositories to %s\n", len(lock.Repos), lockFile)
+	return nil
+}
@@ -21,14 +21,22 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/MoshPitCodes/reposync/internal/config"
 	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/report"
 	"github.com/MoshPitCodes/reposync/internal/tui"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
 )
 
 var (
 	githubOwner string
+	githubHost  string
 	batchMode   bool
 
+	// Repository selection for --batch --all
+	batchAll    bool
+	batchFilter github.Filter
+
 	githubCmd = &cobra.Command{
 		Use:   "github [repos...]",
 		Short: "Synchronize repositories from GitHub",
@@ -43,7 +51,19 @@ func init() {
 	rootCmd.AddCommand(githubCmd)
 
 	githubCmd.Flags().StringVar(&githubOwner, "owner", "", "GitHub owner/organization (defaults to REPOSYNC_GITHUB_OWNER env var)")
+	githubCmd.Flags().StringVar(&githubHost, "host", "", "GitHub host for the owner, e.g. a GitHub Enterprise Server instance (defaults to the owner's persisted host)")
 	githubCmd.Flags().BoolVar(&batchMode, "batch", false, "Batch mode: clone specified repositories without interaction")
+	addBatchFlags(githubCmd)
+	addCloneFlags(githubCmd)
+
+	githubCmd.Flags().BoolVar(&batchAll, "all", false, "Batch mode: clone every repository of the owner that passes the filters")
+	githubCmd.Flags().StringSliceVar(&batchFilter.Match, "match", nil, "With --all: only repositories whose name matches a glob, or a /regex/")
+	githubCmd.Flags().StringSliceVar(&batchFilter.Exclude, "exclude", nil, "With --all: skip repositories whose name matches a glob, or a /regex/")
+	githubCmd.Flags().StringSliceVar(&batchFilter.Topics, "topic", nil, "With --all: only repositories with all of these topics")
+	githubCmd.Flags().StringSliceVar(&batchFilter.Languages, "language", nil, "With --all: only repositories in any of these languages")
+	githubCmd.Flags().StringVar(&batchFilter.Visibility, "visibility", github.VisibilityAll, "With --all: all, public, or private")
+	githubCmd.Flags().BoolVar(&batchFilter.IncludeArchived, "include-archived", false, "With --all: include archived repositories")
+	githubCmd.Flags().BoolVar(&batchFilter.IncludeForks, "include-forks", false, "With --all: include forks")
 }
 
 // runGitHub handles the github subcommand.
@@ -58,32 +78,99 @@ func runGitHub(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("GitHub owner must be specified via --owner flag or REPOSYNC_GITHUB_OWNER env var")
 	}
 
+	// Flag takes precedence over the owner's persisted host
+	if githubHost != "" {
+		cfg.OwnerHosts = map[string]string{owner: githubHost}
+	}
+
+	if batchAll && !batchMode {
+		return fmt.Errorf("--all requires --batch")
+	}
+
 	// Batch mode: clone specified repos directly
 	if batchMode {
-		if len(args) == 0 {
-			return fmt.Errorf("batch mode requires at least one repository name")
+		switch {
+		case batchAll && len(args) > 0:
+			return fmt.Errorf("--all cannot be combined with repository names")
+		case !batchAll && len(args) == 0:
+			return fmt.Errorf("batch mode requires at least one repository name, or --all")
+		case !batchAll && filterFlagsChanged(cmd):
+			return fmt.Errorf("repository filters require --all")
 		}
+		if err := batchFilter.Validate(); err != nil {
+			return err
+		}
+		format, err := parseBatchFlags()
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+
+		// Host, protocol, layout and clone overrides fall back to the persisted settings
+		settings := cfg.MergeWithPersisted(loadPersistedConfig())
 
-		client, err := github.NewClient()
+		client, err := github.NewClientForHost(settings.HostForOwner(owner))
 		if err != nil {
 			return fmt.Errorf("failed to initialize GitHub client: %w", err)
 		}
 
+		protocol, err := github.ParseProtocol(settings.Protocol)
+		if err != nil {
+			return err
+		}
+		client.SetProtocol(protocol)
+
+		repoLayout, err := settings.GetLayout()
+		if err != nil {
+			return err
+		}
+		client.SetLayout(repoLayout)
+
 		targetDir, err := cfg.GetTargetDir()
 		if err != nil {
 			return fmt.Errorf("failed to get target directory: %w", err)
 		}
 
-		for _, repoName := range args {
-			fmt.Printf("Cloning %s/%s...\n", owner, repoName)
-			if err := client.CloneRepo(owner, repoName, targetDir); err != nil {
-				fmt.Fprintf(os.Stderr, "Error cloning %s: %v\n", repoName, err)
-				continue
+		repos := make([]github.Repository, len(args))
+		for i, repoName := range args {
+			repos[i] = github.Repository{
+				Name:     repoName,
+				FullName: owner + "/" + repoName,
+			}
+		}
+		if batchAll {
+			listed, err := client.ListOwnerRepos(owner)
+			if err != nil {
+				return err
+			}
+			if repos, err = batchFilter.Apply(listed); err != nil {
+				return err
 			}
-			fmt.Printf("Successfully cloned %s\n", repoName)
 		}
 
-		return nil
+		targets := make([]batchTarget, len(repos))
+		for i, repo := range repos {
+			targets[i] = batchTarget{name: repo.FullName, path: client.RepoPath(targetDir, repo)}
+		}
+
+		out := report.NewWriter(os.Stdout, os.Stderr, format, report.VerbClone)
+		runBatch(targets, settings.GetJobs(), out, func(indices []int, progressFn func(event workerpool.Event)) {
+			missing := make([]github.Repository, len(indices))
+			for n, i := range indices {
+				missing[n] = repos[i]
+			}
+			client.CloneRepos(missing, targetDir, settings.GetJobs(), settings.CloneOptionsFor, progressFn)
+		}, client.RefreshRepo)
+		if err := out.Flush(); err != nil {
+			return err
+		}
+
+		if rate, err := client.GetRateLimit(); err == nil {
+			out.Note("GitHub API quota: %s", rate)
+		}
+
+		failed, total := out.Counts()
+		return batchError("clone", failed, total)
 	}
 
 	// Interactive mode: launch TUI with GitHub context
@@ -100,3 +187,27 @@ func runGitHub(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// filterFlagsChanged reports whether any --all filter flag was given.
+func filterFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"match", "exclude", "topic", "language", "visibility", "include-archived", "include-forks"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPersistedConfig loads the persisted settings, returning an empty config
+// if none can be read.
+func loadPersistedConfig() *config.PersistedConfig {
+	store, err := config.NewConfigStore()
+	if err != nil {
+		return &config.PersistedConfig{}
+	}
+	persistedCfg, err := store.Load()
+	if err != nil {
+		return &config.PersistedConfig{}
+	}
+	return persistedCfg
+}
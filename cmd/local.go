@@ -22,10 +22,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/report"
 	"github.com/MoshPitCodes/reposync/internal/tui"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
 )
 
 var (
+	copyMode      string
+	copyReference bool
+
 	localCmd = &cobra.Command{
 		Use:   "local [paths...]",
 		Short: "Synchronize local repositories",
@@ -40,6 +45,10 @@ func init() {
 	rootCmd.AddCommand(localCmd)
 
 	localCmd.Flags().BoolVar(&batchMode, "batch", false, "Batch mode: copy specified repositories without interaction")
+	addBatchFlags(localCmd)
+	addCloneFlags(localCmd)
+	localCmd.Flags().StringVar(&copyMode, "copy-mode", "", "How repositories are copied: clone, preserve, or mirror (defaults to REPOSYNC_COPY_MODE env var)")
+	localCmd.Flags().BoolVar(&copyReference, "reference", false, "Borrow objects from the source instead of duplicating them")
 }
 
 // runLocal handles the local subcommand.
@@ -49,23 +58,56 @@ func runLocal(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return fmt.Errorf("batch mode requires at least one repository path")
 		}
+		format, err := parseBatchFlags()
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
 
-		scanner := local.NewScanner()
 		targetDir, err := cfg.GetTargetDir()
 		if err != nil {
 			return fmt.Errorf("failed to get target directory: %w", err)
 		}
 
-		for _, repoPath := range args {
-			fmt.Printf("Copying %s...\n", repoPath)
-			if err := scanner.CopyRepo(repoPath, targetDir); err != nil {
-				fmt.Fprintf(os.Stderr, "Error copying %s: %v\n", repoPath, err)
-				continue
+		// Layout and per-repository clone overrides come from the persisted settings
+		settings := cfg.MergeWithPersisted(loadPersistedConfig())
+
+		repoLayout, err := settings.GetLayout()
+		if err != nil {
+			return err
+		}
+		copyOpts, err := settings.CopyOptions()
+		if err != nil {
+			return err
+		}
+		scanner := local.NewScanner()
+		scanner.SetLayout(repoLayout)
+		scanner.SetCopyOptions(copyOpts)
+
+		repos := make([]local.Repository, len(args))
+		targets := make([]batchTarget, len(args))
+		for i, repoPath := range args {
+			repos[i] = local.Repository{
+				Name: repoPath,
+				Path: repoPath,
+			}
+			targets[i] = batchTarget{name: repoPath, path: scanner.DestPath(repoPath, targetDir)}
+		}
+
+		out := report.NewWriter(os.Stdout, os.Stderr, format, report.VerbCopy)
+		runBatch(targets, settings.GetJobs(), out, func(indices []int, progressFn func(event workerpool.Event)) {
+			missing := make([]local.Repository, len(indices))
+			for n, i := range indices {
+				missing[n] = repos[i]
 			}
-			fmt.Printf("Successfully copied %s\n", repoPath)
+			scanner.CopyRepos(missing, targetDir, settings.GetJobs(), settings.CloneOptionsFor, progressFn)
+		}, scanner.RefreshRepo)
+		if err := out.Flush(); err != nil {
+			return err
 		}
 
-		return nil
+		failed, total := out.Counts()
+		return batchError("copy", failed, total)
 	}
 
 	// Interactive mode: launch TUI with local context
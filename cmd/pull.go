@@ -0,0 +1,87 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/report"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+var pullCmd = &cobra.Command{
+	Use:   "pull [dirs...]",
+	Short: "Fetch and fast-forward every repository in the workspace",
+	Long: `Fetch and fast-forward every repository in the workspace.
+Without arguments, the target directory and the configured source directories
+are scanned. All repositories are fetched in parallel first. A repository is
+then fast-forwarded only if its working tree is clean and it is on a branch
+tracking an upstream; otherwise it is skipped with the reason (dirty,
+diverged, detached or no upstream). No merge commits are created.`,
+	RunE: runPull,
+}
+
+func init() {
+	rootCmd.AddCommand(pullCmd)
+
+	pullCmd.Flags().StringVarP(&outputFormat, "output", "o", string(report.FormatText), "Output format: text, table, json, or ndjson")
+}
+
+// runPull handles the pull subcommand.
+func runPull(cmd *cobra.Command, args []string) error {
+	format, err := report.ParseFormat(outputFormat)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	repos, err := findWorkspaceRepos(args)
+	if err != nil {
+		return err
+	}
+
+	// The worker count falls back to the persisted settings
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+	out := report.NewWriter(os.Stdout, os.Stderr, format, report.VerbPull)
+	results := local.NewScanner().PullRepos(repos, settings.GetJobs(), func(event workerpool.Event) {
+		if event.Status == workerpool.StatusStarted {
+			out.Started(event.Name)
+		}
+	})
+
+	for _, result := range results {
+		record := report.Record{Name: result.Name, Path: result.Path, Duration: result.Duration, Err: result.Err}
+		switch result.Outcome {
+		case local.PullUpdated:
+			record.Action = report.ActionRefreshed
+		case local.PullUpToDate:
+			record.Action = report.ActionUnchanged
+		case local.PullSkipped:
+			record.Action, record.Reason = report.ActionSkipped, result.Reason
+		default:
+			record.Action = report.ActionFailed
+		}
+		out.Add(record)
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+
+	failed, total := out.Counts()
+	return batchError("pull", failed, total)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/lockfile"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Clone or check out the exact commits recorded in a lockfile",
+	Long: `Clone or check out the exact commits recorded in a lockfile.
+Missing repositories are cloned from their recorded remote. Existing ones are
+fetched if needed and checked out at the pinned commit; repositories with
+uncommitted changes are skipped and reported as failed.`,
+	Args: cobra.NoArgs,
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().StringVarP(&lockFile, "file", "f", lockfile.DefaultFile, "Path to the lockfile")
+}
+
+// runRestore handles the restore subcommand.
+func runRestore(cmd *cobra.Command, args []string) error {
+	lock, err := lockfile.Load(lockFile)
+	if err != nil {
+		return err
+	}
+
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+	targetDir, err := settings.GetTargetDir()
+	if err != nil {
+		return fmt.Errorf("failed to get target directory: %w", err)
+	}
+
+	var mu sync.Mutex
+	results := lock.Restore(targetDir, settings.GetJobs(), func(event workerpool.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if event.Status == workerpool.StatusStarted {
+			fmt.Printf("Restoring %s...\n", event.Name)
+		}
+	})
+
+	// Per-repository report
+	failures := 0
+	fmt.Println()
+	for _, result := range results {
+		commit := result.Entry.Commit
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+
+		if result.Err != nil {
+			failures++
+			fmt.Fprintf(os.Stderr, "%-12s %s: %v\n", result.Outcome, result.Entry.Path, result.Err)
+			continue
+		}
+		fmt.Printf("%-12s %s @ %s\n", result.Outcome, result.Entry.Path, commit)
+	}
+
+	return batchError("restore", failures, len(results))
+}
@@ -21,12 +21,17 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/MoshPitCodes/reposync/internal/clone"
 	"github.com/MoshPitCodes/reposync/internal/config"
+	"github.com/MoshPitCodes/reposync/internal/github"
 	"github.com/MoshPitCodes/reposync/internal/tui"
 )
 
 var (
-	cfg *config.Config
+	cfg           *config.Config
+	jobs          int
+	protocol      string
+	layoutPattern string
 
 	rootCmd = &cobra.Command{
 		Use:   "reposync",
@@ -44,6 +49,10 @@ func Execute() error {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().IntVarP(&jobs, "jobs", "j", 0, "Number of repositories to clone/copy in parallel (defaults to REPOSYNC_JOBS env var)")
+	rootCmd.PersistentFlags().StringVar(&layoutPattern, "layout", "", "Path pattern inside the target directory, e.g. {host}/{owner}/{repo} (defaults to REPOSYNC_LAYOUT env var)")
+	rootCmd.PersistentFlags().StringVar(&protocol, "protocol", "", "Clone protocol: ssh, https, or gh (defaults to REPOSYNC_PROTOCOL env var)")
 }
 
 // initConfig loads configuration from environment variables.
@@ -54,6 +63,48 @@ func initConfig() {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Flag takes precedence over environment and persisted config
+	if jobs > 0 {
+		cfg.Jobs = jobs
+	}
+	if protocol != "" {
+		cfg.Protocol = protocol
+	}
+	if layoutPattern != "" {
+		cfg.Layout = layoutPattern
+	}
+	if copyMode != "" {
+		cfg.CopyMode = copyMode
+	}
+	if copyReference {
+		cfg.CopyReference = true
+	}
+	if _, err := cfg.GetLayout(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := cfg.CopyOptions(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Protocol != "" {
+		if _, err := github.ParseProtocol(cfg.Protocol); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	cfg.Clone = clone.Options{
+		Depth:        cloneDepth,
+		Filter:       cloneFilter,
+		SingleBranch: cloneSingleBranch,
+		SparsePaths:  cloneSparse,
+	}
+	if err := cfg.Clone.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
 }
 
 // runInteractive launches the interactive TUI menu.
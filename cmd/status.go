@@ -0,0 +1,227 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/report"
+)
+
+var (
+	statusOutput string
+	statusDirty  bool
+	statusAhead  bool
+	statusBehind bool
+
+	statusCmd = &cobra.Command{
+		Use:   "status [dirs...]",
+		Short: "Show the git status of every repository in the workspace",
+		Long: `Show the git status of every repository in the workspace.
+Without arguments, the target directory and the configured source directories
+are scanned. Each repository's branch, changed and untracked file counts,
+commits ahead of and behind its upstream, stash count and last commit age are
+reported.`,
+		RunE: runStatus,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVarP(&statusOutput, "output", "o", string(report.FormatTable), "Output format: table, json, or ndjson")
+	statusCmd.Flags().BoolVar(&statusDirty, "dirty", false, "Only show repositories with changed or untracked files")
+	statusCmd.Flags().BoolVar(&statusAhead, "ahead", false, "Only show repositories with unpushed commits")
+	statusCmd.Flags().BoolVar(&statusBehind, "behind", false, "Only show repositories behind their upstream")
+}
+
+// statusRecord is the machine-readable form of a repository status.
+type statusRecord struct {
+	Name       string     `json:"name"`
+	Path       string     `json:"path"`
+	Branch     string     `json:"branch,omitempty"`
+	Upstream   string     `json:"upstream,omitempty"`
+	Ahead      int        `json:"ahead"`
+	Behind     int        `json:"behind"`
+	Changed    int        `json:"changed"`
+	Untracked  int        `json:"untracked"`
+	Stashes    int        `json:"stashes"`
+	LastCommit *time.Time `json:"last_commit,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// runStatus handles the status subcommand.
+func runStatus(cmd *cobra.Command, args []string) error {
+	format, err := report.ParseFormat(statusOutput)
+	if err != nil {
+		return err
+	}
+	if format == report.FormatText {
+		format = report.FormatTable
+	}
+	cmd.SilenceUsage = true
+
+	repos, err := findWorkspaceRepos(args)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, len(repos))
+	for i, repo := range repos {
+		paths[i] = repo.Path
+	}
+
+	// The worker count falls back to the persisted settings
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+	statuses := local.NewScanner().GetStatuses(paths, settings.GetJobs())
+
+	var records []statusRecord
+	failed := 0
+	for i, status := range statuses {
+		if status.Err != nil {
+			failed++
+		} else if (statusDirty && !status.IsDirty()) || (statusAhead && status.Ahead == 0) || (statusBehind && status.Behind == 0) {
+			continue
+		}
+		records = append(records, newStatusRecord(repos[i].Name, status))
+	}
+
+	if err := writeStatus(records, format); err != nil {
+		return err
+	}
+	return batchError("status", failed, len(statuses))
+}
+
+// findWorkspaceRepos finds the repositories in dirs, or in the target
+// directory and configured source directories when dirs is empty. Each
+// repository is named relative to the directory it was found in, and a
+// repository reachable from several directories is returned once.
+func findWorkspaceRepos(dirs []string) ([]local.Repository, error) {
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+	roots := dirs
+	if len(roots) == 0 {
+		roots = append([]string{settings.TargetDir}, settings.SourceDirs...)
+	}
+
+	scanner := local.NewScanner()
+	scanner.SetScanOptions(settings.ScanOptions())
+	var repos []local.Repository
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		repoPaths, err := scanner.FindRepositories(root)
+		if err != nil {
+			if len(dirs) > 0 {
+				return nil, err
+			}
+			continue // Configured directories may not exist yet
+		}
+		for _, repoPath := range repoPaths {
+			abs, err := filepath.Abs(repoPath)
+			if err != nil || seen[abs] {
+				continue
+			}
+			seen[abs] = true
+
+			name := filepath.Base(repoPath)
+			if rel, err := filepath.Rel(root, repoPath); err == nil && rel != "." {
+				name = filepath.ToSlash(rel)
+			}
+			repos = append(repos, local.Repository{Name: name, Path: abs, IsGitRepo: true})
+		}
+	}
+
+	return repos, nil
+}
+
+// newStatusRecord converts a status into its output record.
+func newStatusRecord(name string, status local.Status) statusRecord {
+	record := statusRecord{
+		Name:      name,
+		Path:      status.Path,
+		Branch:    status.Branch,
+		Upstream:  status.Upstream,
+		Ahead:     status.Ahead,
+		Behind:    status.Behind,
+		Changed:   status.Changed,
+		Untracked: status.Untracked,
+		Stashes:   status.Stashes,
+	}
+	if !status.LastCommit.IsZero() {
+		record.LastCommit = &status.LastCommit
+	}
+	if status.Err != nil {
+		record.Error = status.Err.Error()
+	}
+	return record
+}
+
+// writeStatus writes the status records to stdout in the given format.
+func writeStatus(records []statusRecord, format report.Format) error {
+	switch format {
+	case report.FormatJSON:
+		if records == nil {
+			records = []statusRecord{}
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal status: %w", err)
+		}
+		fmt.Printf("%s\n", data)
+		return nil
+
+	case report.FormatNDJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to marshal status: %w", err)
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPOSITORY\tBRANCH\tCHANGED\tUNTRACKED\tAHEAD\tBEHIND\tSTASHES\tLAST COMMIT")
+	for _, r := range records {
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%s\terror: %s\t\t\t\t\t\t\n", r.Name, r.Error)
+			continue
+		}
+
+		branch := r.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		ahead, behind := "-", "-"
+		if r.Upstream != "" {
+			ahead, behind = strconv.Itoa(r.Ahead), strconv.Itoa(r.Behind)
+		}
+		lastCommit := "-"
+		if r.LastCommit != nil {
+			lastCommit = local.FormatAge(*r.LastCommit)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\t%d\t%s\n",
+			r.Name, branch, r.Changed, r.Untracked, ahead, behind, r.Stashes, lastCommit)
+	}
+	return tw.Flush()
+}
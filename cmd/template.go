@@ -0,0 +1,315 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/template"
+)
+
+var (
+	templatePaths     []string
+	templateRef       string
+	templateDryRun    bool
+	templateOverwrite bool
+
+	templateCmd = &cobra.Command{
+		Use:   "template",
+		Short: "Sync files from a template repository into local repositories",
+	}
+
+	templateSyncCmd = &cobra.Command{
+		Use:   "sync <template> <targets...>",
+		Short: "Copy template files into local repositories",
+		Long: `Copy files from a template into local repositories.
+The template is a local directory or a GitHub repository given as owner/repo.
+Every file of the template is synced unless --path limits the selection.
+Files that don't exist in a target are created. Files synced before are
+merged with the template's changes since the last sync, writing conflict
+markers where both sides changed the same lines. Other files that differ are
+only overwritten with --overwrite. Use --dry-run to print the plan with a unified
+diff of every change without writing anything.
+Each target records the synced files in ` + template.LockFile + `.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: runTemplateSync,
+	}
+
+	templateStatusCmd = &cobra.Command{
+		Use:   "status <targets...>",
+		Short: "Show how synced template files drifted in local repositories",
+		Long: `Compare the files recorded in each target's ` + template.LockFile + ` with
+the target and with the template they were synced from. Each file is up to
+date, changed in the template, modified locally, or changed on both sides.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runTemplateStatus,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateSyncCmd)
+	templateCmd.AddCommand(templateStatusCmd)
+
+	templateSyncCmd.Flags().StringSliceVar(&templatePaths, "path", nil, "Comma-separated template files or directories to sync (default: all files)")
+	templateSyncCmd.Flags().StringVar(&templateRef, "ref", "", "Branch, tag or commit of a GitHub template (default: its default branch)")
+	templateSyncCmd.Flags().BoolVar(&templateDryRun, "dry-run", false, "Print the plan and diffs without writing anything")
+	templateSyncCmd.Flags().BoolVar(&templateOverwrite, "overwrite", false, "Overwrite target files that differ from the template")
+}
+
+// runTemplateSync handles the template sync subcommand.
+func runTemplateSync(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	engine, err := newTemplateEngine(args[0], templateRef)
+	if err != nil {
+		return err
+	}
+
+	targets, err := templateTargets(args[1:])
+	if err != nil {
+		return err
+	}
+
+	files, err := engine.ListFiles()
+	if err != nil {
+		return err
+	}
+	files = selectTemplateFiles(files, templatePaths)
+	if len(files) == 0 {
+		return fmt.Errorf("no template files match %s", strings.Join(templatePaths, ", "))
+	}
+
+	changes, err := engine.Plan(files, targets, nil)
+	if err != nil {
+		return err
+	}
+	printTemplatePlan(changes, templateDryRun)
+	if templateDryRun {
+		return nil
+	}
+
+	// Differing files are only replaced when asked to
+	engine.SetOverwriteAll(templateOverwrite)
+	results := engine.ApplyChanges(changes, nil, nil)
+
+	failedTargets := make(map[string]bool)
+	for _, result := range results {
+		if result.Error != nil {
+			failedTargets[result.TargetRepo] = true
+			fmt.Fprintf(os.Stderr, "Error syncing %s to %s: %v\n", result.FilePath, result.TargetRepo, result.Error)
+		}
+	}
+
+	// Report every merge so conflicts can be found and resolved
+	merges := template.SummarizeMerges(results)
+	if len(merges) > 0 {
+		fmt.Println("\nMerges:")
+		for _, result := range results {
+			if result.Merge != template.MergeNone {
+				fmt.Printf("  %-10s  %s\n", result.Merge, filepath.Join(result.TargetRepo, result.FilePath))
+			}
+		}
+	}
+
+	synced, unchanged, skipped, errors := template.GetSyncSummary(results)
+	fmt.Printf("\n%d synced, %d unchanged, %d skipped, %d failed\n", synced, unchanged, skipped, errors)
+	if merges[template.MergeConflicted] > 0 {
+		fmt.Println("Resolve the conflict markers in the conflicted files before committing.")
+	}
+	if !templateOverwrite && template.SummarizePlan(changes)[template.PlanUpdate] > 0 {
+		fmt.Println("Re-run with --overwrite to replace files that differ from the template.")
+	}
+
+	return batchError("template sync", len(failedTargets), len(targets))
+}
+
+// runTemplateStatus handles the template status subcommand.
+func runTemplateStatus(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	targets, err := templateTargets(args)
+	if err != nil {
+		return err
+	}
+
+	statuses := template.CheckDrift(targets, newTemplateEngine)
+
+	failedTargets := make(map[string]bool)
+	for i, target := range targets {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		var group []template.FileStatus
+		for _, status := range statuses {
+			if status.TargetRepo == target {
+				group = append(group, status)
+			}
+		}
+		if len(group) == 0 {
+			fmt.Printf("==> %s (no template files synced)\n", target)
+			continue
+		}
+
+		fmt.Printf("==> %s\n", target)
+		for _, status := range group {
+			if status.Err != nil {
+				failedTargets[target] = true
+				fmt.Printf("  %-16s  %s: %v\n", "error", status.Path, status.Err)
+				continue
+			}
+			line := fmt.Sprintf("  %-16s  %s", status.State, status.Path)
+			if status.Detail != "" {
+				line += " (" + status.Detail + ")"
+			}
+			fmt.Println(line)
+		}
+	}
+
+	counts := template.SummarizeDrift(statuses)
+	fmt.Printf("\nStatus: %d up to date, %d template changed, %d locally modified, %d both changed\n",
+		counts[template.DriftUpToDate], counts[template.DriftUpstream], counts[template.DriftLocal], counts[template.DriftBoth])
+
+	return batchError("template status", len(failedTargets), len(targets))
+}
+
+// templateTargets resolves target repository arguments to absolute paths.
+func templateTargets(args []string) ([]string, error) {
+	targets := make([]string, len(args))
+	for i, target := range args {
+		info, err := os.Stat(target)
+		if err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("target is not a directory: %s", target)
+		}
+		if targets[i], err = filepath.Abs(target); err != nil {
+			return nil, fmt.Errorf("failed to resolve target path: %w", err)
+		}
+	}
+	return targets, nil
+}
+
+// newTemplateEngine creates a sync engine for a local template directory or
+// an owner/repo GitHub template at ref, which defaults to the default branch.
+func newTemplateEngine(source, ref string) (*template.SyncEngine, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve template path: %w", err)
+		}
+		return template.NewLocalSyncEngine(abs), nil
+	}
+
+	owner, repo, ok := strings.Cut(source, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("template must be a local directory or owner/repo: %s", source)
+	}
+
+	settings := cfg.MergeWithPersisted(loadPersistedConfig())
+	client, err := github.NewClientForHost(settings.HostForOwner(owner))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize GitHub client: %w", err)
+	}
+
+	if ref == "" {
+		if ref, err = client.GetDefaultBranch(owner, repo); err != nil {
+			return nil, fmt.Errorf("failed to get default branch: %w", err)
+		}
+	}
+	return template.NewSyncEngine(client, owner, repo, ref), nil
+}
+
+// selectTemplateFiles returns the files equal to or below one of paths, or
+// every file when paths is empty.
+func selectTemplateFiles(files, paths []string) []string {
+	if len(paths) == 0 {
+		return files
+	}
+
+	var selected []string
+	for _, file := range files {
+		for _, p := range paths {
+			p = strings.Trim(filepath.ToSlash(p), "/")
+			if file == p || strings.HasPrefix(file, p+"/") {
+				selected = append(selected, file)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// printTemplatePlan prints the planned action for every file, grouped by
+// target, followed by a count per action. With diffs, each target's
+// changes are followed by their unified diffs.
+func printTemplatePlan(changes []template.Change, diffs bool) {
+	for start := 0; start < len(changes); {
+		end := start
+		for end < len(changes) && changes[end].TargetRepo == changes[start].TargetRepo {
+			end++
+		}
+		group := changes[start:end]
+
+		if start > 0 {
+			fmt.Println()
+		}
+		// Files that already match the template are only counted
+		identical := 0
+		for _, change := range group {
+			if change.Action == template.PlanIdentical {
+				identical++
+			}
+		}
+		switch identical {
+		case 0:
+			fmt.Printf("==> %s\n", group[0].TargetRepo)
+		case len(group):
+			fmt.Printf("==> %s (up to date)\n", group[0].TargetRepo)
+		default:
+			fmt.Printf("==> %s (%d identical)\n", group[0].TargetRepo, identical)
+		}
+		for _, change := range group {
+			if change.Action == template.PlanIdentical {
+				continue
+			}
+			line := fmt.Sprintf("  %-9s  %s", change.Action, change.FilePath)
+			if change.Merge != template.MergeNone {
+				line += " (" + change.Merge.String() + ")"
+			}
+			if change.Reason != "" {
+				line += ": " + change.Reason
+			}
+			fmt.Println(line)
+		}
+		if diffs {
+			for _, change := range group {
+				if change.Diff != "" {
+					fmt.Printf("\n%s", change.Diff)
+				}
+			}
+		}
+		start = end
+	}
+
+	counts := template.SummarizePlan(changes)
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to merge, %d identical, %d skipped\n",
+		counts[template.PlanCreate], counts[template.PlanUpdate], counts[template.PlanMerge], counts[template.PlanIdentical], counts[template.PlanSkip])
+}
@@ -0,0 +1,160 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clone describes how repositories are cloned: shallow, partial,
+// single-branch and sparse clones.
+package clone
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// FilterBlobless is the partial clone filter that omits file contents until
+// they are needed.
+const FilterBlobless = "blob:none"
+
+// Options controls how a repository is cloned. The zero value is a full clone.
+type Options struct {
+	// Depth limits history to the given number of commits; 0 clones everything
+	Depth int `json:"depth,omitempty" yaml:"depth,omitempty"`
+
+	// Filter is a partial clone filter such as "blob:none"
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+
+	// SingleBranch clones only the default branch
+	SingleBranch bool `json:"single_branch,omitempty" yaml:"single_branch,omitempty"`
+
+	// SparsePaths restricts the working tree to these directories (cone mode)
+	SparsePaths []string `json:"sparse_paths,omitempty" yaml:"sparse_paths,omitempty"`
+}
+
+// OptionsFunc returns the options to clone a repository with. The id is the
+// repository's full name for GitHub and its source path for local copies.
+type OptionsFunc func(id string) Options
+
+// IsZero reports whether o describes a plain full clone.
+func (o Options) IsZero() bool {
+	return o.Depth == 0 && o.Filter == "" && !o.SingleBranch && len(o.SparsePaths) == 0
+}
+
+// Validate checks that the options can be passed to git.
+func (o Options) Validate() error {
+	if o.Depth < 0 {
+		return fmt.Errorf("invalid clone depth %d: must not be negative", o.Depth)
+	}
+	for _, path := range o.SparsePaths {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("invalid sparse path: must not be empty")
+		}
+	}
+	return nil
+}
+
+// Merge returns o with every field that is set in override replaced.
+func (o Options) Merge(override Options) Options {
+	if override.Depth > 0 {
+		o.Depth = override.Depth
+	}
+	if override.Filter != "" {
+		o.Filter = override.Filter
+	}
+	if override.SingleBranch {
+		o.SingleBranch = true
+	}
+	if len(override.SparsePaths) > 0 {
+		o.SparsePaths = override.SparsePaths
+	}
+	return o
+}
+
+// Args returns the git clone flags for o.
+func (o Options) Args() []string {
+	var args []string
+	if o.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(o.Depth))
+	}
+	if o.Filter != "" {
+		args = append(args, "--filter="+o.Filter)
+	}
+	if o.SingleBranch {
+		args = append(args, "--single-branch")
+	}
+	if len(o.SparsePaths) > 0 {
+		args = append(args, "--sparse")
+	}
+	return args
+}
+
+// NeedsTransport reports whether the options only take effect when git talks
+// to the source through a transport. Local clones ignore --depth and
+// --filter unless the source is given as a file:// URL.
+func (o Options) NeedsTransport() bool {
+	return o.Depth > 0 || o.Filter != ""
+}
+
+// ApplySparse restricts the working tree of a clone made with o to its sparse
+// paths. It does nothing when no sparse paths are set.
+func (o Options) ApplySparse(repoPath string) error {
+	if len(o.SparsePaths) == 0 {
+		return nil
+	}
+
+	args := append([]string{"-C", repoPath, "sparse-checkout", "set", "--cone"}, o.SparsePaths...)
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		errMsg := strings.TrimSpace(string(output))
+		if errMsg != "" {
+			return fmt.Errorf("git sparse-checkout failed: %s", errMsg)
+		}
+		return fmt.Errorf("git sparse-checkout failed: %w", err)
+	}
+	return nil
+}
+
+// String returns a short human-readable summary, e.g. "depth 1, blob:none".
+func (o Options) String() string {
+	if o.IsZero() {
+		return "full clone"
+	}
+
+	var parts []string
+	if o.Depth > 0 {
+		parts = append(parts, fmt.Sprintf("depth %d", o.Depth))
+	}
+	if o.Filter != "" {
+		parts = append(parts, o.Filter)
+	}
+	if o.SingleBranch {
+		parts = append(parts, "single branch")
+	}
+	if len(o.SparsePaths) > 0 {
+		parts = append(parts, "sparse: "+strings.Join(o.SparsePaths, ", "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseSparsePaths splits a comma-separated list of sparse paths, dropping
+// empty entries.
+func ParseSparsePaths(value string) []string {
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,63 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		expected []string
+	}{
+		{name: "full clone", opts: Options{}, expected: nil},
+		{name: "shallow", opts: Options{Depth: 1}, expected: []string{"--depth", "1"}},
+		{
+			name:     "all options",
+			opts:     Options{Depth: 5, Filter: FilterBlobless, SingleBranch: true, SparsePaths: []string{"a"}},
+			expected: []string{"--depth", "5", "--filter=blob:none", "--single-branch", "--sparse"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.opts.Args())
+		})
+	}
+}
+
+func TestOptionsMerge(t *testing.T) {
+	batch := Options{Depth: 1, SingleBranch: true}
+	override := Options{Filter: FilterBlobless, SparsePaths: []string{"services/api"}}
+
+	merged := batch.Merge(override)
+
+	assert.Equal(t, Options{
+		Depth:        1,
+		Filter:       FilterBlobless,
+		SingleBranch: true,
+		SparsePaths:  []string{"services/api"},
+	}, merged)
+	assert.Equal(t, batch, batch.Merge(Options{}), "empty override keeps batch options")
+}
+
+func TestParseSparsePaths(t *testing.T) {
+	assert.Equal(t, []string{"a", "b/c"}, ParseSparsePaths(" a, ,b/c,"))
+	assert.Nil(t, ParseSparsePaths(""))
+}
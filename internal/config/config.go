@@ -16,10 +16,20 @@ package config
 
 import (
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/layout"
+	"github.com/MoshPitCodes/reposync/internal/local"
 )
 
+// DefaultJobs is the number of repositories cloned or copied in parallel
+// when no job count is configured.
+const DefaultJobs = 4
+
 // Config holds application configuration loaded from environment variables.
 type Config struct {
 	// TargetDir is the default directory where repositories will be cloned/copied
@@ -30,6 +40,40 @@ type Config struct {
 
 	// SourceDirs is a list of local directories to scan for repositories
 	SourceDirs []string
+
+	// Jobs is the number of repositories cloned or copied in parallel
+	Jobs int
+
+	// Protocol is the clone protocol: ssh, https or gh
+	Protocol string
+
+	// Layout is the path pattern for repositories inside TargetDir, e.g.
+	// "{host}/{owner}/{repo}"
+	Layout string
+
+	// ScanMaxDepth is how many directory levels below each source directory
+	// are searched for repositories; 0 searches the whole tree
+	ScanMaxDepth int
+
+	// ScanIgnore holds gitignore-style patterns for directories to skip
+	// while searching for repositories
+	ScanIgnore []string
+
+	// CopyMode is how local repositories are copied: clone, preserve or mirror
+	CopyMode string
+
+	// CopyReference makes local copies borrow objects from their source
+	// instead of duplicating them
+	CopyReference bool
+
+	// OwnerHosts maps owners to their GitHub Enterprise Server host
+	OwnerHosts map[string]string
+
+	// Clone holds the clone options selected for the current batch
+	Clone clone.Options
+
+	// CloneOverrides holds per-repository clone options
+	CloneOverrides map[string]clone.Options
 }
 
 // Load reads configuration from environment variables with sensible defaults.
@@ -53,6 +97,25 @@ func Load() (*Config, error) {
 		}
 	}
 
+	// REPOSYNC_JOBS: Number of parallel clone/copy workers
+	if jobs, err := strconv.Atoi(os.Getenv("REPOSYNC_JOBS")); err == nil && jobs > 0 {
+		cfg.Jobs = jobs
+	}
+
+	// REPOSYNC_SCAN_MAX_DEPTH: Directory levels searched for local repositories
+	if depth, err := strconv.Atoi(os.Getenv("REPOSYNC_SCAN_MAX_DEPTH")); err == nil && depth > 0 {
+		cfg.ScanMaxDepth = depth
+	}
+
+	// REPOSYNC_COPY_MODE: How local repositories are copied (clone, preserve or mirror)
+	cfg.CopyMode = strings.ToLower(strings.TrimSpace(os.Getenv("REPOSYNC_COPY_MODE")))
+
+	// REPOSYNC_LAYOUT: Path pattern for repositories inside the target directory
+	cfg.Layout = strings.TrimSpace(os.Getenv("REPOSYNC_LAYOUT"))
+
+	// REPOSYNC_PROTOCOL: Clone protocol (ssh, https or gh)
+	cfg.Protocol = strings.ToLower(strings.TrimSpace(os.Getenv("REPOSYNC_PROTOCOL")))
+
 	return cfg, nil
 }
 
@@ -64,13 +127,91 @@ func (c *Config) GetTargetDir() (string, error) {
 	return c.TargetDir, nil
 }
 
+// GetJobs returns the number of parallel workers, falling back to DefaultJobs.
+func (c *Config) GetJobs() int {
+	if c.Jobs <= 0 {
+		return DefaultJobs
+	}
+	return c.Jobs
+}
+
+// GetLayout parses the configured layout pattern, falling back to the flat
+// default layout when none is set.
+func (c *Config) GetLayout() (layout.Layout, error) {
+	return layout.Parse(c.Layout)
+}
+
+// ScanOptions returns the options for searching directories for repositories.
+func (c *Config) ScanOptions() local.ScanOptions {
+	return local.ScanOptions{
+		MaxDepth: c.ScanMaxDepth,
+		Ignore:   c.ScanIgnore,
+	}
+}
+
+// CopyOptions returns the options for copying local repositories. It fails
+// when the configured copy mode is invalid.
+func (c *Config) CopyOptions() (local.CopyOptions, error) {
+	mode, err := local.ParseCopyMode(c.CopyMode)
+	if err != nil {
+		return local.CopyOptions{}, err
+	}
+	return local.CopyOptions{Mode: mode, Reference: c.CopyReference}, nil
+}
+
+// HostForOwner returns the GitHub host configured for owner, or an empty
+// string when the owner uses the default host. Owners match case-insensitively.
+func (c *Config) HostForOwner(owner string) string {
+	for name, host := range c.OwnerHosts {
+		if strings.EqualFold(name, owner) {
+			return host
+		}
+	}
+	return ""
+}
+
+// CloneOptionsFor returns the clone options for a repository identified by
+// "owner/repo" or its local source path. Per-repository overrides, matched
+// by full identifier first and then by repository name, take precedence over
+// the batch options.
+func (c *Config) CloneOptionsFor(id string) clone.Options {
+	if override, ok := c.CloneOverrides[id]; ok {
+		return c.Clone.Merge(override)
+	}
+	if override, ok := c.CloneOverrides[path.Base(filepath.ToSlash(id))]; ok {
+		return c.Clone.Merge(override)
+	}
+	return c.Clone
+}
+
 // MergeWithPersisted merges the persisted config with this config.
 // Environment variables take precedence over persisted values.
 func (c *Config) MergeWithPersisted(p *PersistedConfig) *Config {
 	merged := &Config{
-		TargetDir:   c.TargetDir,
-		GitHubOwner: c.GitHubOwner,
-		SourceDirs:  c.SourceDirs,
+		TargetDir:     c.TargetDir,
+		GitHubOwner:   c.GitHubOwner,
+		SourceDirs:    c.SourceDirs,
+		Jobs:          c.Jobs,
+		Protocol:      c.Protocol,
+		Layout:        c.Layout,
+		ScanMaxDepth:  c.ScanMaxDepth,
+		ScanIgnore:    c.ScanIgnore,
+		CopyMode:      c.CopyMode,
+		CopyReference: c.CopyReference,
+		Clone:         c.Clone,
+	}
+
+	// Owner hosts set at runtime (e.g. via --host) override persisted ones
+	if (p != nil && len(p.OwnerHosts) > 0) || len(c.OwnerHosts) > 0 {
+		merged.OwnerHosts = make(map[string]string)
+		if p != nil {
+			for owner, host := range p.OwnerHosts {
+				merged.OwnerHosts[owner] = host
+			}
+		}
+		for owner, host := range c.OwnerHosts {
+			merged.OwnerHosts[owner] = host
+		}
 	}
 
 	// Use persisted values only if environment variables are not set
@@ -100,6 +241,40 @@ func (c *Config) MergeWithPersisted(p *PersistedConfig) *Config {
 		}
 	}
 
+	if merged.Jobs <= 0 && p != nil && p.Jobs > 0 {
+		merged.Jobs = p.Jobs
+	}
+
+	if len(c.CloneOverrides) > 0 {
+		merged.CloneOverrides = c.CloneOverrides
+	} else if p != nil {
+		merged.CloneOverrides = p.CloneOverrides
+	}
+
+	if merged.Layout == "" && p != nil && p.Layout != "" {
+		merged.Layout = p.Layout
+	}
+
+	if merged.Protocol == "" && p != nil && p.Protocol != "" {
+		merged.Protocol = p.Protocol
+	}
+
+	if merged.ScanMaxDepth <= 0 && p != nil && p.ScanMaxDepth > 0 {
+		merged.ScanMaxDepth = p.ScanMaxDepth
+	}
+
+	if len(merged.ScanIgnore) == 0 && p != nil {
+		merged.ScanIgnore = p.ScanIgnore
+	}
+
+	if merged.CopyMode == "" && p != nil && p.CopyMode != "" {
+		merged.CopyMode = p.CopyMode
+	}
+
+	if !merged.CopyReference && p != nil {
+		merged.CopyReference = p.CopyReference
+	}
+
 	return merged
 }
 
@@ -21,6 +21,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/local"
 )
 
 func TestMergeWithPersisted(t *testing.T) {
@@ -170,6 +173,8 @@ func TestLoad(t *testing.T) {
 		t.Setenv("REPOSYNC_TARGET_DIR", "/tmp/test")
 		t.Setenv("REPOSYNC_GITHUB_OWNER", "testowner")
 		t.Setenv("REPOSYNC_SOURCE_DIRS", "/path1:/path2:/path3")
+		t.Setenv("REPOSYNC_JOBS", "6")
+		t.Setenv("REPOSYNC_PROTOCOL", "HTTPS")
 
 		cfg, loadErr := Load()
 		require.NoError(t, loadErr)
@@ -177,6 +182,8 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, "/tmp/test", cfg.TargetDir)
 		assert.Equal(t, "testowner", cfg.GitHubOwner)
 		assert.Equal(t, []string{"/path1", "/path2", "/path3"}, cfg.SourceDirs)
+		assert.Equal(t, 6, cfg.Jobs)
+		assert.Equal(t, "https", cfg.Protocol)
 	})
 
 	t.Run("empty values when env vars not set", func(t *testing.T) {
@@ -295,3 +302,114 @@ func TestMergeWithPersistedSourceDirs(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeWithPersistedJobs(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseConfig   *Config
+		persistedCfg *PersistedConfig
+		expectedJobs int
+	}{
+		{
+			name:         "env jobs take precedence over persisted",
+			baseConfig:   &Config{Jobs: 8},
+			persistedCfg: &PersistedConfig{Jobs: 2},
+			expectedJobs: 8,
+		},
+		{
+			name:         "persisted jobs used when env not set",
+			baseConfig:   &Config{},
+			persistedCfg: &PersistedConfig{Jobs: 2},
+			expectedJobs: 2,
+		},
+		{
+			name:         "default used when neither env nor persisted set",
+			baseConfig:   &Config{},
+			persistedCfg: nil,
+			expectedJobs: DefaultJobs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := tt.baseConfig.MergeWithPersisted(tt.persistedCfg)
+			assert.Equal(t, tt.expectedJobs, merged.GetJobs())
+		})
+	}
+}
+
+func TestMergeWithPersistedOwnerHosts(t *testing.T) {
+	base := &Config{OwnerHosts: map[string]string{"platform": "ghe.override.com"}}
+	persisted := &PersistedConfig{OwnerHosts: map[string]string{
+		"platform": "ghe.example.com",
+		"Infra":    "ghe.example.com",
+	}}
+
+	merged := base.MergeWithPersisted(persisted)
+
+	assert.Equal(t, "ghe.override.com", merged.HostForOwner("platform"), "runtime host should win")
+	assert.Equal(t, "ghe.example.com", merged.HostForOwner("infra"), "owners match case-insensitively")
+	assert.Equal(t, "", merged.HostForOwner("MoshPitCodes"), "unlisted owners use the default host")
+	assert.Equal(t, "ghe.example.com", persisted.OwnerHosts["platform"], "persisted config must not be modified")
+}
+
+func TestMergeWithPersistedScanOptions(t *testing.T) {
+	persisted := &PersistedConfig{ScanMaxDepth: 3, ScanIgnore: []string{"archive"}}
+
+	opts := (&Config{}).MergeWithPersisted(persisted).ScanOptions()
+	assert.Equal(t, 3, opts.MaxDepth)
+	assert.Equal(t, []string{"archive"}, opts.Ignore)
+
+	opts = (&Config{ScanMaxDepth: 5}).MergeWithPersisted(persisted).ScanOptions()
+	assert.Equal(t, 5, opts.MaxDepth, "env max depth should win")
+}
+
+func TestMergeWithPersistedCopyOptions(t *testing.T) {
+	persisted := &PersistedConfig{CopyMode: "preserve", CopyReference: true}
+
+	opts, err := (&Config{}).MergeWithPersisted(persisted).CopyOptions()
+	require.NoError(t, err)
+	assert.Equal(t, local.CopyPreserve, opts.Mode)
+	assert.True(t, opts.Reference)
+
+	_, err = (&Config{CopyMode: "move"}).MergeWithPersisted(persisted).CopyOptions()
+	assert.Error(t, err, "invalid env copy mode should be rejected")
+}
+
+func TestCloneOptionsFor(t *testing.T) {
+	cfg := &Config{
+		Clone: clone.Options{SingleBranch: true},
+		CloneOverrides: map[string]clone.Options{
+			"acme/monorepo": {Depth: 1, Filter: clone.FilterBlobless},
+			"docs":          {SparsePaths: []string{"site"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		expected clone.Options
+	}{
+		{
+			name:     "override by full name",
+			id:       "acme/monorepo",
+			expected: clone.Options{Depth: 1, Filter: clone.FilterBlobless, SingleBranch: true},
+		},
+		{
+			name:     "override by repository name",
+			id:       "/home/user/src/docs",
+			expected: clone.Options{SingleBranch: true, SparsePaths: []string{"site"}},
+		},
+		{
+			name:     "batch options without override",
+			id:       "acme/other",
+			expected: clone.Options{SingleBranch: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cfg.CloneOptionsFor(tt.id))
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
 )
 
 // PersistedConfig represents configuration stored in the config file.
@@ -28,6 +30,21 @@ type PersistedConfig struct {
 	DefaultOwner    string   `json:"default_owner,omitempty"`
 	RecentOwners    []string `json:"recent_owners,omitempty"`
 	RecentTemplates []string `json:"recent_templates,omitempty"`
+	Jobs            int      `json:"jobs,omitempty"`
+	Protocol        string   `json:"protocol,omitempty"`
+	Layout          string   `json:"layout,omitempty"`
+	ScanMaxDepth    int      `json:"scan_max_depth,omitempty"`
+	ScanIgnore      []string `json:"scan_ignore,omitempty"`
+	CopyMode        string   `json:"copy_mode,omitempty"`
+	CopyReference   bool     `json:"copy_reference,omitempty"`
+
+	// OwnerHosts maps owners to the GitHub host they live on, for owners on
+	// GitHub Enterprise Server. Owners not listed use the default host.
+	OwnerHosts map[string]string `json:"owner_hosts,omitempty"`
+
+	// CloneOverrides holds clone options for individual repositories, keyed
+	// by "owner/repo", local source path, or bare repository name.
+	CloneOverrides map[string]clone.Options `json:"clone_overrides,omitempty"`
 }
 
 // ConfigStore handles persistent storage of configuration.
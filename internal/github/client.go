@@ -17,15 +17,78 @@ package github
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/layout"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+// DefaultHost is the GitHub host used when none is configured.
+const DefaultHost = "github.com"
+
+// Protocol selects how repositories are cloned.
+type Protocol string
+
+const (
+	// ProtocolSSH clones over SSH using the user's SSH keys.
+	ProtocolSSH Protocol = "ssh"
+	// ProtocolHTTPS clones over HTTPS using git's configured credential helpers.
+	ProtocolHTTPS Protocol = "https"
+	// ProtocolGH clones over HTTPS using `gh auth token` as the credential helper.
+	ProtocolGH Protocol = "gh"
 )
 
+// ParseProtocol validates a protocol name. An empty name selects SSH.
+func ParseProtocol(name string) (Protocol, error) {
+	switch Protocol(strings.ToLower(strings.TrimSpace(name))) {
+	case "", ProtocolSSH:
+		return ProtocolSSH, nil
+	case ProtocolHTTPS:
+		return ProtocolHTTPS, nil
+	case ProtocolGH:
+		return ProtocolGH, nil
+	default:
+		return "", fmt.Errorf("unknown clone protocol %q (expected ssh, https or gh)", name)
+	}
+}
+
+// Affiliations accepted by ListAuthenticatedUserRepos.
+const (
+	AffiliationOwner              = "owner"
+	AffiliationCollaborator       = "collaborator"
+	AffiliationOrganizationMember = "organization_member"
+)
+
+// Visibilities accepted by ListAuthenticatedUserRepos.
+const (
+	VisibilityAll     = "all"
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+// UserRepoOptions filters the repositories of the authenticated user.
+type UserRepoOptions struct {
+	// Affiliations defaults to owner, collaborator and organization_member
+	Affiliations []string
+
+	// Visibility is all, public or private (defaults to all)
+	Visibility string
+}
+
 // Repository represents a GitHub repository with relevant metadata.
 type Repository struct {
 	Name          string
@@ -33,10 +96,65 @@ type Repository struct {
 	Description   string
 	Language      string
 	Stars         int
-	CloneURL      string
+	CloneURL      string // HTTPS clone URL
+	SSHURL        string
 	IsPrivate     bool
 	IsArchived    bool
 	DefaultBranch string
+	PushedAt      time.Time
+	UpdatedAt     time.Time
+	Size          int // Size in kilobytes, as reported by the API
+	Topics        []string
+	Fork          bool
+}
+
+// LastActivity returns when the repository was last pushed to, falling back
+// to its last metadata update.
+func (r Repository) LastActivity() time.Time {
+	if r.PushedAt.After(r.UpdatedAt) {
+		return r.PushedAt
+	}
+	return r.UpdatedAt
+}
+
+// apiRepository is the repository representation returned by the REST API.
+type apiRepository struct {
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Description   string    `json:"description"`
+	Language      string    `json:"language"`
+	Stars         int       `json:"stargazers_count"`
+	CloneURL      string    `json:"clone_url"`
+	SSHURL        string    `json:"ssh_url"`
+	Private       bool      `json:"private"`
+	Archived      bool      `json:"archived"`
+	DefaultBranch string    `json:"default_branch"`
+	PushedAt      time.Time `json:"pushed_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Size          int       `json:"size"`
+	Topics        []string  `json:"topics"`
+	Fork          bool      `json:"fork"`
+}
+
+// toRepository converts an API repository into a Repository.
+func (r apiRepository) toRepository() Repository {
+	return Repository{
+		Name:          r.Name,
+		FullName:      r.FullName,
+		Description:   r.Description,
+		Language:      r.Language,
+		Stars:         r.Stars,
+		CloneURL:      r.CloneURL,
+		SSHURL:        r.SSHURL,
+		IsPrivate:     r.Private,
+		IsArchived:    r.Archived,
+		DefaultBranch: r.DefaultBranch,
+		PushedAt:      r.PushedAt,
+		UpdatedAt:     r.UpdatedAt,
+		Size:          r.Size,
+		Topics:        r.Topics,
+		Fork:          r.Fork,
+	}
 }
 
 // TreeEntry represents a single entry in a repository tree.
@@ -56,52 +174,170 @@ type TreeResponse struct {
 
 // Client handles GitHub API interactions using go-gh.
 type Client struct {
-	client *api.RESTClient
+	client    *api.RESTClient
+	transport *rateLimitTransport
+	protocol  Protocol
+	host      string
+	layout    layout.Layout
+
+	loginMu sync.Mutex
+	login   string // Cached login of the authenticated user
 }
 
 // NewClient creates a new GitHub client using the existing gh CLI authentication.
+// Requests rejected by GitHub's rate limits are retried with backoff.
 func NewClient() (*Client, error) {
-	opts := api.ClientOptions{}
+	return NewClientForHost("")
+}
+
+// NewClientForHost creates a GitHub client for the given host, such as a
+// GitHub Enterprise Server instance. An empty host selects gh's default host.
+func NewClientForHost(host string) (*Client, error) {
+	if host == "" {
+		host, _ = auth.DefaultHost()
+	}
+	host = auth.NormalizeHostname(host)
+
+	transport := newRateLimitTransport(http.DefaultTransport)
+	opts := api.ClientOptions{
+		Host:      host,
+		Transport: transport,
+	}
 	client, err := api.NewRESTClient(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GitHub REST client: %w", err)
+		return nil, fmt.Errorf("failed to create GitHub REST client for %s: %w", host, err)
 	}
 
-	return &Client{client: client}, nil
+	return &Client{client: client, transport: transport, protocol: ProtocolSSH, host: host}, nil
 }
 
-// ListUserRepos retrieves all repositories for a user.
+// Host returns the GitHub host this client talks to.
+func (c *Client) Host() string {
+	if c.host == "" {
+		return DefaultHost
+	}
+	return c.host
+}
+
+// SetProtocol sets the protocol used for cloning repositories.
+func (c *Client) SetProtocol(protocol Protocol) {
+	c.protocol = protocol
+}
+
+// SetLayout sets the layout that decides where repositories are cloned.
+func (c *Client) SetLayout(l layout.Layout) {
+	c.layout = l
+}
+
+// RepoPath returns the directory repo is cloned into under targetDir.
+func (c *Client) RepoPath(targetDir string, repo Repository) string {
+	owner, _, _ := strings.Cut(repo.FullName, "/")
+	return c.layout.Path(targetDir, layout.Fields{
+		Host:  c.Host(),
+		Owner: owner,
+		Repo:  repo.Name,
+	})
+}
+
+// RateLimit returns the API quota reported by the most recent response and
+// whether any response has been seen yet.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	return c.transport.RateLimit()
+}
+
+// GetRateLimit fetches the current core API quota. Querying the rate limit
+// endpoint does not count against the quota.
+func (c *Client) GetRateLimit() (RateLimit, error) {
+	var result struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+
+	if err := c.client.Get("rate_limit", &result); err != nil {
+		return RateLimit{}, fmt.Errorf("failed to fetch rate limit: %w", err)
+	}
+
+	core := result.Resources.Core
+	return RateLimit{
+		Limit:     core.Limit,
+		Remaining: core.Remaining,
+		Reset:     time.Unix(core.Reset, 0),
+	}, nil
+}
+
+// ListUserRepos retrieves all repositories for a user. For the authenticated
+// user this includes private repositories, which users/{username}/repos omits.
 func (c *Client) ListUserRepos(username string) ([]Repository, error) {
-	return c.listRepos(fmt.Sprintf("users/%s/repos", username))
+	if login, err := c.GetCurrentUser(); err == nil && strings.EqualFold(login, username) {
+		return c.ListAuthenticatedUserRepos(UserRepoOptions{})
+	}
+	return c.listRepos(fmt.Sprintf("users/%s/repos", username), nil)
+}
+
+// ListAuthenticatedUserRepos retrieves the repositories the authenticated user
+// can access, including private ones, filtered by affiliation and visibility.
+func (c *Client) ListAuthenticatedUserRepos(opts UserRepoOptions) ([]Repository, error) {
+	affiliations := opts.Affiliations
+	if len(affiliations) == 0 {
+		affiliations = []string{AffiliationOwner, AffiliationCollaborator, AffiliationOrganizationMember}
+	}
+	visibility := opts.Visibility
+	if visibility == "" {
+		visibility = VisibilityAll
+	}
+
+	query := url.Values{}
+	query.Set("affiliation", strings.Join(affiliations, ","))
+	query.Set("visibility", visibility)
+	return c.listRepos("user/repos", query)
 }
 
 // ListOrgRepos retrieves all repositories for an organization.
 func (c *Client) ListOrgRepos(orgName string) ([]Repository, error) {
-	return c.listRepos(fmt.Sprintf("orgs/%s/repos", orgName))
+	return c.listRepos(fmt.Sprintf("orgs/%s/repos", orgName), nil)
+}
+
+// ListOwnerRepos retrieves the repositories owned by a user or organization.
+// For the authenticated user this includes private repositories.
+func (c *Client) ListOwnerRepos(owner string) ([]Repository, error) {
+	if login, err := c.GetCurrentUser(); err == nil && strings.EqualFold(login, owner) {
+		return c.ListAuthenticatedUserRepos(UserRepoOptions{Affiliations: []string{AffiliationOwner}})
+	}
+
+	repos, err := c.ListOrgRepos(owner)
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		// Not an organization; fall back to the user's public repositories
+		return c.listRepos(fmt.Sprintf("users/%s/repos", owner), nil)
+	}
+	return repos, err
 }
 
 // listRepos is a generic method to list repositories from an API endpoint.
-func (c *Client) listRepos(endpoint string) ([]Repository, error) {
+// Additional query parameters are appended to the pagination parameters.
+func (c *Client) listRepos(endpoint string, query url.Values) ([]Repository, error) {
 	var allRepos []Repository
 	page := 1
 	perPage := 100
 
 	for {
-		var repos []struct {
-			Name        string `json:"name"`
-			FullName    string `json:"full_name"`
-			Description string `json:"description"`
-			Language    string `json:"language"`
-			Stars       int    `json:"stargazers_count"`
-			CloneURL    string `json:"clone_url"`
-			SSHURL      string `json:"ssh_url"`
-			Private     bool   `json:"private"`
-			Archived    bool   `json:"archived"`
-		}
+		var repos []apiRepository
 
-		url := fmt.Sprintf("%s?per_page=%d&page=%d&sort=updated&direction=desc", endpoint, perPage, page)
+		params := url.Values{}
+		for key, values := range query {
+			params[key] = values
+		}
+		params.Set("per_page", strconv.Itoa(perPage))
+		params.Set("page", strconv.Itoa(page))
+		params.Set("sort", "updated")
+		params.Set("direction", "desc")
 
-		err := c.client.Get(url, &repos)
+		err := c.client.Get(endpoint+"?"+params.Encode(), &repos)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch repositories: %w", err)
 		}
@@ -111,16 +347,7 @@ func (c *Client) listRepos(endpoint string) ([]Repository, error) {
 		}
 
 		for _, repo := range repos {
-			allRepos = append(allRepos, Repository{
-				Name:        repo.Name,
-				FullName:    repo.FullName,
-				Description: repo.Description,
-				Language:    repo.Language,
-				Stars:       repo.Stars,
-				CloneURL:    repo.SSHURL, // Prefer SSH for authenticated access
-				IsPrivate:   repo.Private,
-				IsArchived:  repo.Archived,
-			})
+			allRepos = append(allRepos, repo.toRepository())
 		}
 
 		page++
@@ -130,24 +357,46 @@ func (c *Client) listRepos(endpoint string) ([]Repository, error) {
 }
 
 // CloneRepo clones a repository to the specified target directory.
-func (c *Client) CloneRepo(owner, repoName, targetDir string) error {
-	// Construct the SSH clone URL
-	cloneURL := fmt.Sprintf("git@github.com:%s/%s.git", owner, repoName)
+func (c *Client) CloneRepo(owner, repoName, targetDir string, opts clone.Options) error {
+	return c.cloneRepository(Repository{
+		Name:     repoName,
+		FullName: owner + "/" + repoName,
+	}, targetDir, opts)
+}
 
-	repoPath := filepath.Join(targetDir, repoName)
+// cloneRepository clones repo into targetDir using the configured protocol.
+func (c *Client) cloneRepository(repo Repository, targetDir string, opts clone.Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := c.RepoPath(targetDir, repo)
 
 	// Check if directory already exists
 	if _, err := os.Stat(repoPath); err == nil {
 		return fmt.Errorf("repository directory already exists: %s", repoPath)
 	}
 
-	// Create target directory if it doesn't exist
-	if err := os.MkdirAll(targetDir, 0o755); err != nil {
+	// Create the parent directories required by the layout
+	if err := os.MkdirAll(filepath.Dir(repoPath), 0o755); err != nil {
 		return fmt.Errorf("failed to create target directory: %w", err)
 	}
 
 	// Clone the repository using git command
-	cmd := exec.Command("git", "clone", cloneURL, repoPath)
+	args := []string{"clone"}
+	if c.protocol == ProtocolGH {
+		// Reset inherited helpers so only gh supplies credentials. The helper
+		// is stored in the clone's config so later fetches keep working; the
+		// token itself is never written to disk.
+		args = append(args,
+			"--config", "credential.helper=",
+			"--config", "credential.helper="+ghCredentialHelper(c.Host()),
+		)
+	}
+	args = append(args, opts.Args()...)
+	args = append(args, c.cloneURL(repo), repoPath)
+
+	cmd := exec.Command("git", args...)
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
@@ -159,31 +408,59 @@ func (c *Client) CloneRepo(owner, repoName, targetDir string) error {
 		return fmt.Errorf("git clone failed: %w", err)
 	}
 
-	return nil
+	return opts.ApplySparse(repoPath)
 }
 
-// CloneRepos clones multiple repositories concurrently with progress reporting.
-func (c *Client) CloneRepos(repos []Repository, targetDir string, progressFn func(repo string, success bool, err error)) {
-	for _, repo := range repos {
-		owner := strings.Split(repo.FullName, "/")[0]
-		err := c.CloneRepo(owner, repo.Name, targetDir)
-		progressFn(repo.Name, err == nil, err)
+// cloneURL returns the URL to clone repo with, preferring the URLs reported
+// by the API and falling back to ones built from the full name.
+func (c *Client) cloneURL(repo Repository) string {
+	switch c.protocol {
+	case ProtocolHTTPS, ProtocolGH:
+		if repo.CloneURL != "" {
+			return repo.CloneURL
+		}
+		return fmt.Sprintf("https://%s/%s.git", c.Host(), repo.FullName)
+	default:
+		if repo.SSHURL != "" {
+			return repo.SSHURL
+		}
+		return fmt.Sprintf("git@%s:%s.git", c.Host(), repo.FullName)
 	}
 }
 
+// ghCredentialHelper returns a git credential helper that answers with the
+// token from `gh auth token` for the given host.
+func ghCredentialHelper(host string) string {
+	return fmt.Sprintf(`!f() { test "$1" = get || exit 0; echo username=x-access-token; echo "password=$(gh auth token --hostname %s)"; }; f`, host)
+}
+
+// CloneRepos clones multiple repositories concurrently using at most jobs workers.
+// optsFn selects the clone options for each repository by full name; nil
+// clones everything in full.
+// progressFn is called from the worker goroutines when each clone starts and
+// finishes, so it must be safe for concurrent use.
+func (c *Client) CloneRepos(repos []Repository, targetDir string, jobs int, optsFn clone.OptionsFunc, progressFn func(event workerpool.Event)) {
+	workerpool.Run(jobs, len(repos), func(i int) {
+		repo := repos[i]
+		if progressFn != nil {
+			progressFn(workerpool.Started(i, repo.Name))
+		}
+
+		var opts clone.Options
+		if optsFn != nil {
+			opts = optsFn(repo.FullName)
+		}
+		err := c.cloneRepository(repo, targetDir, opts)
+
+		if progressFn != nil {
+			progressFn(workerpool.Finished(i, repo.Name, err))
+		}
+	})
+}
+
 // GetRepoDetails fetches detailed information about a specific repository.
 func (c *Client) GetRepoDetails(owner, repoName string) (*Repository, error) {
-	var repo struct {
-		Name        string `json:"name"`
-		FullName    string `json:"full_name"`
-		Description string `json:"description"`
-		Language    string `json:"language"`
-		Stars       int    `json:"stargazers_count"`
-		CloneURL    string `json:"clone_url"`
-		SSHURL      string `json:"ssh_url"`
-		Private     bool   `json:"private"`
-		Archived    bool   `json:"archived"`
-	}
+	var repo apiRepository
 
 	endpoint := fmt.Sprintf("repos/%s/%s", owner, repoName)
 
@@ -191,16 +468,8 @@ func (c *Client) GetRepoDetails(owner, repoName string) (*Repository, error) {
 		return nil, fmt.Errorf("failed to fetch repository details: %w", err)
 	}
 
-	return &Repository{
-		Name:        repo.Name,
-		FullName:    repo.FullName,
-		Description: repo.Description,
-		Language:    repo.Language,
-		Stars:       repo.Stars,
-		CloneURL:    repo.SSHURL,
-		IsPrivate:   repo.Private,
-		IsArchived:  repo.Archived,
-	}, nil
+	details := repo.toRepository()
+	return &details, nil
 }
 
 // IsAuthenticated checks if the user is authenticated with GitHub CLI.
@@ -213,7 +482,15 @@ func IsAuthenticated() bool {
 }
 
 // GetCurrentUser retrieves the authenticated user's login.
+// The login is cached after the first successful lookup.
 func (c *Client) GetCurrentUser() (string, error) {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	if c.login != "" {
+		return c.login, nil
+	}
+
 	var user struct {
 		Login string `json:"login"`
 	}
@@ -222,23 +499,14 @@ func (c *Client) GetCurrentUser() (string, error) {
 		return "", fmt.Errorf("failed to get current user: %w", err)
 	}
 
+	c.login = user.Login
 	return user.Login, nil
 }
 
 // SearchRepos searches for repositories matching a query.
 func (c *Client) SearchRepos(query string, owner string) ([]Repository, error) {
 	var result struct {
-		Items []struct {
-			Name        string `json:"name"`
-			FullName    string `json:"full_name"`
-			Description string `json:"description"`
-			Language    string `json:"language"`
-			Stars       int    `json:"stargazers_count"`
-			CloneURL    string `json:"clone_url"`
-			SSHURL      string `json:"ssh_url"`
-			Private     bool   `json:"private"`
-			Archived    bool   `json:"archived"`
-		} `json:"items"`
+		Items []apiRepository `json:"items"`
 	}
 
 	searchQuery := fmt.Sprintf("user:%s %s", owner, query)
@@ -256,16 +524,7 @@ func (c *Client) SearchRepos(query string, owner string) ([]Repository, error) {
 
 	var repos []Repository
 	for _, item := range result.Items {
-		repos = append(repos, Repository{
-			Name:        item.Name,
-			FullName:    item.FullName,
-			Description: item.Description,
-			Language:    item.Language,
-			Stars:       item.Stars,
-			CloneURL:    item.SSHURL,
-			IsPrivate:   item.Private,
-			IsArchived:  item.Archived,
-		})
+		repos = append(repos, item.toRepository())
 	}
 
 	return repos, nil
@@ -292,6 +551,17 @@ func (c *Client) ListUserOrgs() ([]string, error) {
 
 // RefreshRepo performs a git pull on an existing repository.
 func (c *Client) RefreshRepo(repoPath string) error {
+	return pullRepo(repoPath)
+}
+
+// FastForwardRepo pulls an existing repository, failing instead of creating a
+// merge commit when the local branch has diverged.
+func (c *Client) FastForwardRepo(repoPath string) error {
+	return pullRepo(repoPath, "--ff-only")
+}
+
+// pullRepo runs git pull with the given extra arguments.
+func pullRepo(repoPath string, args ...string) error {
 	// Verify the directory exists and is a git repository
 	gitDir := filepath.Join(repoPath, ".git")
 	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
@@ -299,7 +569,7 @@ func (c *Client) RefreshRepo(repoPath string) error {
 	}
 
 	// Run git pull
-	cmd := exec.Command("git", "-C", repoPath, "pull")
+	cmd := exec.Command("git", append([]string{"-C", repoPath, "pull"}, args...)...)
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
@@ -329,6 +599,22 @@ func (c *Client) GetDefaultBranch(owner, repo string) (string, error) {
 	return result.DefaultBranch, nil
 }
 
+// GetCommitSHA resolves a branch, tag or commit of a repository to the
+// full SHA of its commit.
+func (c *Client) GetCommitSHA(owner, repo, ref string) (string, error) {
+	var result struct {
+		SHA string `json:"sha"`
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, ref)
+
+	if err := c.client.Get(endpoint, &result); err != nil {
+		return "", fmt.Errorf("failed to fetch commit: %w", err)
+	}
+
+	return result.SHA, nil
+}
+
 // GetRepoTree fetches the complete file tree of a repository recursively.
 func (c *Client) GetRepoTree(owner, repo, branch string) (*TreeResponse, error) {
 	var result TreeResponse
@@ -342,6 +628,30 @@ func (c *Client) GetRepoTree(owner, repo, branch string) (*TreeResponse, error)
 	return &result, nil
 }
 
+// GetBlob fetches the content of a blob by its SHA.
+func (c *Client) GetBlob(owner, repo, sha string) ([]byte, error) {
+	var result struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/git/blobs/%s", owner, repo, sha)
+
+	if err := c.client.Get(endpoint, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch blob: %w", err)
+	}
+
+	if result.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(result.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode blob: %w", err)
+		}
+		return decoded, nil
+	}
+
+	return []byte(result.Content), nil
+}
+
 // GetFileContent fetches the content of a single file from a repository.
 // The content is automatically base64 decoded.
 func (c *Client) GetFileContent(owner, repo, path, ref string) ([]byte, error) {
@@ -0,0 +1,115 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProtocol(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Protocol
+		wantErr  bool
+	}{
+		{input: "", expected: ProtocolSSH},
+		{input: "ssh", expected: ProtocolSSH},
+		{input: "HTTPS", expected: ProtocolHTTPS},
+		{input: " gh ", expected: ProtocolGH},
+		{input: "git", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			protocol, err := ParseProtocol(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, protocol)
+		})
+	}
+}
+
+func TestCloneURL(t *testing.T) {
+	populated := Repository{
+		Name:     "repo",
+		FullName: "owner/repo",
+		CloneURL: "https://github.com/owner/repo.git",
+		SSHURL:   "git@github.com:owner/repo.git",
+	}
+	bare := Repository{Name: "repo", FullName: "owner/repo"}
+
+	tests := []struct {
+		name     string
+		protocol Protocol
+		host     string
+		repo     Repository
+		expected string
+	}{
+		{name: "ssh uses API URL", protocol: ProtocolSSH, repo: populated, expected: "git@github.com:owner/repo.git"},
+		{name: "https uses API URL", protocol: ProtocolHTTPS, repo: populated, expected: "https://github.com/owner/repo.git"},
+		{name: "ssh built from full name", protocol: ProtocolSSH, repo: bare, expected: "git@github.com:owner/repo.git"},
+		{name: "gh built from full name", protocol: ProtocolGH, repo: bare, expected: "https://github.com/owner/repo.git"},
+		{name: "enterprise host over ssh", protocol: ProtocolSSH, host: "ghe.example.com", repo: bare, expected: "git@ghe.example.com:owner/repo.git"},
+		{name: "enterprise host over https", protocol: ProtocolHTTPS, host: "ghe.example.com", repo: bare, expected: "https://ghe.example.com/owner/repo.git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{protocol: tt.protocol, host: tt.host}
+			assert.Equal(t, tt.expected, c.cloneURL(tt.repo))
+		})
+	}
+}
+
+func TestListAuthenticatedUserRepos(t *testing.T) {
+	var queries []url.Values
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/user/repos", req.URL.Path)
+		queries = append(queries, req.URL.Query())
+
+		body := "[]"
+		if req.URL.Query().Get("page") == "1" {
+			body = `[{"name":"secret","full_name":"me/secret","private":true}]`
+		}
+		return newResponse(http.StatusOK, map[string]string{"Content-Type": "application/json"}, body), nil
+	})
+
+	rest, err := api.NewRESTClient(api.ClientOptions{Host: "github.com", AuthToken: "token", Transport: transport})
+	require.NoError(t, err)
+	c := &Client{client: rest}
+
+	repos, err := c.ListAuthenticatedUserRepos(UserRepoOptions{
+		Affiliations: []string{AffiliationCollaborator},
+		Visibility:   VisibilityPrivate,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, repos, 1)
+	assert.Equal(t, "me/secret", repos[0].FullName)
+	assert.True(t, repos[0].IsPrivate)
+
+	require.NotEmpty(t, queries)
+	assert.Equal(t, "collaborator", queries[0].Get("affiliation"))
+	assert.Equal(t, "private", queries[0].Get("visibility"))
+}
@@ -0,0 +1,191 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Filter selects repositories from a listing. The zero value keeps every
+// repository that is neither archived nor a fork.
+type Filter struct {
+	// Match keeps repositories whose name matches any pattern. Patterns are
+	// globs, or regular expressions when wrapped in slashes (e.g. /^api-/).
+	Match []string
+
+	// Exclude drops repositories whose name matches any pattern
+	Exclude []string
+
+	// Topics keeps repositories that have every listed topic
+	Topics []string
+
+	// Languages keeps repositories whose primary language is any of these
+	Languages []string
+
+	// Visibility is all, public or private (defaults to all)
+	Visibility string
+
+	// IncludeArchived keeps archived repositories
+	IncludeArchived bool
+
+	// IncludeForks keeps forks
+	IncludeForks bool
+}
+
+// namePattern matches repository names against a glob or a regular expression.
+type namePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+// parseNamePattern parses a glob, or a regular expression wrapped in slashes.
+// Globs match case-insensitively.
+func parseNamePattern(pattern string) (namePattern, error) {
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return namePattern{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		return namePattern{re: re}, nil
+	}
+
+	glob := strings.ToLower(pattern)
+	if _, err := path.Match(glob, ""); err != nil {
+		return namePattern{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return namePattern{glob: glob}, nil
+}
+
+// matches reports whether name matches the pattern.
+func (p namePattern) matches(name string) bool {
+	if p.re != nil {
+		return p.re.MatchString(name)
+	}
+	ok, _ := path.Match(p.glob, strings.ToLower(name))
+	return ok
+}
+
+// parseNamePatterns parses every pattern in patterns.
+func parseNamePatterns(patterns []string) ([]namePattern, error) {
+	parsed := make([]namePattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		p, err := parseNamePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// matchesAny reports whether name matches any of the patterns.
+func matchesAny(patterns []namePattern, name string) bool {
+	for _, p := range patterns {
+		if p.matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the visibility and name patterns.
+func (f Filter) Validate() error {
+	switch f.Visibility {
+	case "", VisibilityAll, VisibilityPublic, VisibilityPrivate:
+	default:
+		return fmt.Errorf("invalid visibility %q: must be all, public or private", f.Visibility)
+	}
+	if _, err := parseNamePatterns(f.Match); err != nil {
+		return err
+	}
+	_, err := parseNamePatterns(f.Exclude)
+	return err
+}
+
+// Apply returns the repositories selected by the filter, keeping their order.
+func (f Filter) Apply(repos []Repository) ([]Repository, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	match, err := parseNamePatterns(f.Match)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := parseNamePatterns(f.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []Repository
+	for _, repo := range repos {
+		if repo.IsArchived && !f.IncludeArchived {
+			continue
+		}
+		if repo.Fork && !f.IncludeForks {
+			continue
+		}
+		if (f.Visibility == VisibilityPublic && repo.IsPrivate) || (f.Visibility == VisibilityPrivate && !repo.IsPrivate) {
+			continue
+		}
+		if len(match) > 0 && !matchesAny(match, repo.Name) {
+			continue
+		}
+		if matchesAny(exclude, repo.Name) {
+			continue
+		}
+		if !hasAllTopics(repo, f.Topics) || !hasLanguage(repo, f.Languages) {
+			continue
+		}
+		selected = append(selected, repo)
+	}
+
+	return selected, nil
+}
+
+// hasAllTopics reports whether repo has every topic. Topics are compared
+// case-insensitively.
+func hasAllTopics(repo Repository, topics []string) bool {
+	for _, topic := range topics {
+		found := false
+		for _, t := range repo.Topics {
+			if strings.EqualFold(t, topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLanguage reports whether repo's primary language is one of languages,
+// or languages is empty.
+func hasLanguage(repo Repository, languages []string) bool {
+	if len(languages) == 0 {
+		return true
+	}
+	for _, language := range languages {
+		if strings.EqualFold(repo.Language, language) {
+			return true
+		}
+	}
+	return false
+}
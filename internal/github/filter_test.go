@@ -0,0 +1,69 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterApply(t *testing.T) {
+	repos := []Repository{
+		{Name: "api-gateway", Language: "Go", Topics: []string{"backend", "http"}},
+		{Name: "api-legacy", Language: "Java", Topics: []string{"backend"}, IsArchived: true},
+		{Name: "web", Language: "TypeScript", Topics: []string{"frontend"}, IsPrivate: true},
+		{Name: "api-fork", Language: "Go", Topics: []string{"backend"}, Fork: true},
+		{Name: "billing", Language: "go", Topics: []string{"Backend"}, IsPrivate: true},
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{name: "defaults drop archived and forks", filter: Filter{}, expected: []string{"api-gateway", "web", "billing"}},
+		{name: "include archived and forks", filter: Filter{IncludeArchived: true, IncludeForks: true}, expected: []string{"api-gateway", "api-legacy", "web", "api-fork", "billing"}},
+		{name: "glob match", filter: Filter{Match: []string{"API-*"}}, expected: []string{"api-gateway"}},
+		{name: "regex match", filter: Filter{Match: []string{"/^(web|billing)$/"}}, expected: []string{"web", "billing"}},
+		{name: "exclude", filter: Filter{Exclude: []string{"web"}}, expected: []string{"api-gateway", "billing"}},
+		{name: "all topics required", filter: Filter{Topics: []string{"backend", "http"}}, expected: []string{"api-gateway"}},
+		{name: "topic case-insensitive", filter: Filter{Topics: []string{"backend"}}, expected: []string{"api-gateway", "billing"}},
+		{name: "any language", filter: Filter{Languages: []string{"Go", "TypeScript"}}, expected: []string{"api-gateway", "web", "billing"}},
+		{name: "private only", filter: Filter{Visibility: VisibilityPrivate}, expected: []string{"web", "billing"}},
+		{name: "public only", filter: Filter{Visibility: VisibilityPublic}, expected: []string{"api-gateway"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := tt.filter.Apply(repos)
+			require.NoError(t, err)
+
+			var names []string
+			for _, repo := range selected {
+				names = append(names, repo.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	assert.NoError(t, Filter{Match: []string{"api-*", "/^web$/"}}.Validate())
+	assert.Error(t, Filter{Match: []string{"/[/"}}.Validate())
+	assert.Error(t, Filter{Exclude: []string{"["}}.Validate())
+	assert.Error(t, Filter{Visibility: "internal"}.Validate())
+}
@@ -0,0 +1,193 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRateLimitRetries is the number of times a rate limited request is retried.
+	maxRateLimitRetries = 5
+
+	// maxRateLimitWait is the longest single wait before giving up on a request.
+	// Primary rate limits can take up to an hour to reset, which is not worth
+	// blocking an interactive session for.
+	maxRateLimitWait = 2 * time.Minute
+
+	// baseRateLimitBackoff is the initial backoff for secondary rate limits
+	// that don't specify a Retry-After header.
+	baseRateLimitBackoff = 2 * time.Second
+)
+
+// RateLimit holds the most recently observed GitHub API quota.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// String returns a human-readable summary of the remaining quota.
+func (r RateLimit) String() string {
+	if r.Reset.IsZero() {
+		return fmt.Sprintf("%d/%d remaining", r.Remaining, r.Limit)
+	}
+	return fmt.Sprintf("%d/%d remaining, resets at %s", r.Remaining, r.Limit, r.Reset.Local().Format("15:04"))
+}
+
+// rateLimitTransport is an http.RoundTripper that retries requests rejected by
+// GitHub's primary or secondary rate limits and records the remaining quota.
+type rateLimitTransport struct {
+	base  http.RoundTripper
+	sleep func(time.Duration)
+
+	mu    sync.Mutex
+	rate  RateLimit
+	known bool
+}
+
+// newRateLimitTransport wraps base with rate limit handling.
+func newRateLimitTransport(base http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{
+		base:  base,
+		sleep: time.Sleep,
+	}
+}
+
+// RoundTrip executes the request, sleeping and retrying with jitter while
+// GitHub reports that the client is rate limited.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		t.record(resp.Header)
+
+		wait, limited := rateLimitDelay(resp, attempt)
+		if !limited || attempt >= maxRateLimitRetries || wait > maxRateLimitWait {
+			return resp, nil
+		}
+
+		// Discard the rejected response before retrying
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		t.sleep(wait + jitter(wait))
+	}
+}
+
+// record stores the quota reported in the response headers.
+func (t *rateLimitTransport) record(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	rate := RateLimit{Remaining: remaining}
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		rate.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rate.Reset = time.Unix(reset, 0)
+	}
+
+	t.mu.Lock()
+	t.rate = rate
+	t.known = true
+	t.mu.Unlock()
+}
+
+// RateLimit returns the last observed quota and whether any has been seen yet.
+func (t *rateLimitTransport) RateLimit() (RateLimit, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rate, t.known
+}
+
+// rateLimitDelay reports whether resp was rejected by a rate limit and how
+// long to wait before retrying.
+func rateLimitDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	// Secondary rate limits tell us exactly how long to wait
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	// Primary rate limit exhausted: wait for the reset
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			wait := time.Until(time.Unix(reset, 0)) + time.Second
+			if wait < time.Second {
+				wait = time.Second
+			}
+			return wait, true
+		}
+	}
+
+	// A 403 without rate limit headers is only retried when the body says so,
+	// otherwise it's a genuine permission error
+	if resp.StatusCode == http.StatusForbidden && !mentionsRateLimit(resp) {
+		return 0, false
+	}
+
+	return baseRateLimitBackoff << attempt, true
+}
+
+// mentionsRateLimit checks the response body for a rate limit message while
+// leaving the body readable for the caller.
+func mentionsRateLimit(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(body)), "rate limit")
+}
+
+// jitter returns a random duration of up to a quarter of d.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(d)/4 + 1))
+}
@@ -0,0 +1,155 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newResponse(status int, headers map[string]string, body string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []*http.Response
+		expectedCalls int
+		expectedCode  int
+		expectSleep   bool
+	}{
+		{
+			name: "success is returned immediately",
+			responses: []*http.Response{
+				newResponse(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "42"}, "{}"),
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name: "secondary rate limit retried after Retry-After",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, map[string]string{"Retry-After": "1"}, "secondary rate limit"),
+				newResponse(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "41"}, "{}"),
+			},
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+			expectSleep:   true,
+		},
+		{
+			name: "429 without headers retried with backoff",
+			responses: []*http.Response{
+				newResponse(http.StatusTooManyRequests, nil, ""),
+				newResponse(http.StatusOK, nil, "{}"),
+			},
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+			expectSleep:   true,
+		},
+		{
+			name: "permission error is not retried",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, nil, "Resource not accessible by integration"),
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusForbidden,
+		},
+		{
+			name: "reset too far away is not waited for",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, map[string]string{
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     "9999999999",
+				}, "API rate limit exceeded"),
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+				resp := tt.responses[calls]
+				calls++
+				return resp, nil
+			})
+
+			slept := false
+			transport := newRateLimitTransport(base)
+			transport.sleep = func(time.Duration) { slept = true }
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+			assert.Equal(t, tt.expectSleep, slept)
+		})
+	}
+}
+
+func TestRateLimitTransportRecordsQuota(t *testing.T) {
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "4321",
+			"X-RateLimit-Reset":     "1700000000",
+		}, "{}"), nil
+	})
+
+	transport := newRateLimitTransport(base)
+	_, known := transport.RateLimit()
+	assert.False(t, known)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	rate, known := transport.RateLimit()
+	assert.True(t, known)
+	assert.Equal(t, 5000, rate.Limit)
+	assert.Equal(t, 4321, rate.Remaining)
+	assert.Equal(t, time.Unix(1700000000, 0), rate.Reset)
+}
@@ -0,0 +1,129 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package layout maps repositories to paths inside the target directory
+// using patterns such as "{host}/{owner}/{repo}".
+package layout
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultPattern places every repository directly in the target directory.
+const DefaultPattern = "{repo}"
+
+// LocalHost is the host used for local repositories without a remote.
+const LocalHost = "local"
+
+// placeholderRe matches placeholders such as {owner}.
+var placeholderRe = regexp.MustCompile(`\{[^{}]*\}`)
+
+// Fields are the values substituted into a layout pattern.
+type Fields struct {
+	Host  string
+	Owner string
+	Repo  string
+}
+
+// Layout maps repositories to relative paths. The zero value uses DefaultPattern.
+type Layout struct {
+	pattern string
+}
+
+// Parse validates a layout pattern. Patterns are slash-separated and may use
+// the {host}, {owner} and {repo} placeholders; {repo} is required. An empty
+// pattern selects DefaultPattern.
+func Parse(pattern string) (Layout, error) {
+	pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+	if pattern == "" {
+		return Layout{}, nil
+	}
+
+	for _, placeholder := range placeholderRe.FindAllString(pattern, -1) {
+		switch placeholder {
+		case "{host}", "{owner}", "{repo}":
+		default:
+			return Layout{}, fmt.Errorf("invalid layout %q: unknown placeholder %s", pattern, placeholder)
+		}
+	}
+	if !strings.Contains(pattern, "{repo}") {
+		return Layout{}, fmt.Errorf("invalid layout %q: must contain {repo}", pattern)
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return Layout{}, fmt.Errorf("invalid layout %q: empty or relative path segment", pattern)
+		}
+	}
+
+	return Layout{pattern: pattern}, nil
+}
+
+// Pattern returns the layout pattern.
+func (l Layout) Pattern() string {
+	if l.pattern == "" {
+		return DefaultPattern
+	}
+	return l.pattern
+}
+
+// Path returns where a repository is placed inside targetDir.
+func (l Layout) Path(targetDir string, f Fields) string {
+	replacer := strings.NewReplacer(
+		"{host}", segment(f.Host, LocalHost),
+		"{owner}", segment(f.Owner, "_"),
+		"{repo}", segment(f.Repo, "_"),
+	)
+	return filepath.Join(targetDir, filepath.FromSlash(replacer.Replace(l.Pattern())))
+}
+
+// segment makes a field safe to use as a single path segment.
+func segment(value, fallback string) string {
+	value = strings.NewReplacer("/", "_", "\\", "_").Replace(strings.TrimSpace(value))
+	if value == "" || value == "." || value == ".." {
+		return fallback
+	}
+	return value
+}
+
+// scpLikeRe matches scp-like git URLs such as git@github.com:owner/repo.git.
+var scpLikeRe = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// ParseRemoteURL extracts the host, owner and repository name from a git
+// remote URL. It reports false for URLs without an owner, such as local paths.
+func ParseRemoteURL(remote string) (Fields, bool) {
+	remote = strings.TrimSpace(remote)
+
+	var host, repoPath string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, repoPath = u.Hostname(), u.Path
+	} else if m := scpLikeRe.FindStringSubmatch(remote); m != nil && !filepath.IsAbs(remote) {
+		host, repoPath = m[1], m[2]
+	} else {
+		return Fields{}, false
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	owner, repo := path.Split(repoPath)
+	owner = strings.Trim(owner, "/")
+	if host == "" || owner == "" || repo == "" {
+		return Fields{}, false
+	}
+
+	return Fields{Host: host, Owner: owner, Repo: repo}, true
+}
@@ -0,0 +1,89 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layout
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "empty uses default", pattern: "", expected: DefaultPattern},
+		{name: "owner namespaced", pattern: "{owner}/{repo}", expected: "{owner}/{repo}"},
+		{name: "surrounding slashes trimmed", pattern: "/{host}/{owner}/{repo}/", expected: "{host}/{owner}/{repo}"},
+		{name: "missing repo", pattern: "{owner}", wantErr: true},
+		{name: "unknown placeholder", pattern: "{org}/{repo}", wantErr: true},
+		{name: "relative segment", pattern: "../{repo}", wantErr: true},
+		{name: "empty segment", pattern: "{owner}//{repo}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := Parse(tt.pattern)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, l.Pattern())
+		})
+	}
+}
+
+func TestLayoutPath(t *testing.T) {
+	l, err := Parse("{host}/{owner}/{repo}")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		filepath.Join("/repos", "github.com", "acme", "api"),
+		l.Path("/repos", Fields{Host: "github.com", Owner: "acme", Repo: "api"}))
+	assert.Equal(t,
+		filepath.Join("/repos", LocalHost, "_", "tools_cli"),
+		l.Path("/repos", Fields{Repo: "tools/cli"}), "missing fields fall back and slashes are sanitized")
+	assert.Equal(t,
+		filepath.Join("/repos", "api"),
+		Layout{}.Path("/repos", Fields{Owner: "acme", Repo: "api"}), "zero value is flat")
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote   string
+		expected Fields
+		ok       bool
+	}{
+		{remote: "git@github.com:acme/api.git", expected: Fields{Host: "github.com", Owner: "acme", Repo: "api"}, ok: true},
+		{remote: "https://ghe.example.com/acme/api.git", expected: Fields{Host: "ghe.example.com", Owner: "acme", Repo: "api"}, ok: true},
+		{remote: "ssh://git@github.com/acme/api", expected: Fields{Host: "github.com", Owner: "acme", Repo: "api"}, ok: true},
+		{remote: "/srv/git/api.git", ok: false},
+		{remote: "https://github.com/api", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			fields, ok := ParseRemoteURL(tt.remote)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
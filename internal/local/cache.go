@@ -0,0 +1,271 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheVersion is bumped whenever the cache format changes, discarding
+// caches written by older versions.
+const cacheVersion = 2
+
+// Cache stores the metadata of scanned repositories on disk, so repositories
+// that haven't changed since the last scan don't need to be analyzed again.
+// An entry is reused while the modification times of the repository's HEAD,
+// index and HEAD reflog are unchanged. It is safe for concurrent use.
+type Cache struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is the cached metadata of a repository.
+type cacheEntry struct {
+	Name       string    `json:"name"`
+	Branch     string    `json:"branch,omitempty"`
+	LastCommit time.Time `json:"last_commit"`
+	Size       int64     `json:"size"`
+	Kind       RepoKind  `json:"kind,omitempty"`
+	Parent     string    `json:"parent,omitempty"`
+	Stamp      []int64   `json:"stamp"` // See repoStamp
+}
+
+// cacheFile is the on-disk format of the cache.
+type cacheFile struct {
+	Version int                   `json:"version"`
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+// OpenCache loads the scan cache from the user cache directory.
+func OpenCache() (*Cache, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cache directory: %w", err)
+	}
+	return LoadCache(filepath.Join(cacheDir, "reposync", "scan-cache.json"))
+}
+
+// LoadCache loads the scan cache at path. A missing, unreadable or outdated
+// cache file results in an empty cache.
+func LoadCache(path string) (*Cache, error) {
+	c := &Cache{path: path, entries: make(map[string]cacheEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("failed to read scan cache: %w", err)
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err == nil && file.Version == cacheVersion && file.Entries != nil {
+		c.entries = file.Entries
+	}
+	return c, nil
+}
+
+// Save writes the cache to disk.
+func (c *Cache) Save() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.Marshal(cacheFile{Version: cacheVersion, Entries: c.entries})
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temporary file first so a concurrent reader never sees a
+	// partially written cache
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".scan-cache-*")
+	if err != nil {
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	return nil
+}
+
+// Repositories returns the cached repositories inside roots, ordered by
+// path. They may be out of date or no longer exist.
+func (c *Cache) Repositories(roots []string) []Repository {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var repos []Repository
+	for path, entry := range c.entries {
+		if !underAny(path, roots) {
+			continue
+		}
+		repos = append(repos, entry.repository(path))
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Path < repos[j].Path })
+	return repos
+}
+
+// lookup returns the cached repository at repoPath if it is still current,
+// along with the repository's current stamp for storing a fresh entry.
+func (c *Cache) lookup(repoPath string) (Repository, []int64, bool) {
+	stamp := repoStamp(repoPath)
+	if c == nil {
+		return Repository{}, stamp, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[repoPath]
+	c.mu.Unlock()
+
+	if !ok || !slices.Equal(entry.Stamp, stamp) {
+		return Repository{}, stamp, false
+	}
+	return entry.repository(repoPath), stamp, true
+}
+
+// store caches repo under the stamp taken before it was analyzed.
+func (c *Cache) store(repo Repository, stamp []int64) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[repo.Path] = cacheEntry{
+		Name:       repo.Name,
+		Branch:     repo.Branch,
+		LastCommit: repo.LastCommit,
+		Size:       repo.Size,
+		Kind:       repo.Kind,
+		Parent:     repo.Parent,
+		Stamp:      stamp,
+	}
+}
+
+// prune removes the entries inside roots that aren't in seen, i.e. the
+// repositories a complete scan of roots no longer found.
+func (c *Cache) prune(roots []string, seen map[string]bool) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for path := range c.entries {
+		if underAny(path, roots) && !seen[path] {
+			delete(c.entries, path)
+		}
+	}
+}
+
+// repository converts a cache entry back into a repository.
+func (e cacheEntry) repository(path string) Repository {
+	return Repository{
+		Name:       e.Name,
+		Path:       path,
+		IsGitRepo:  true,
+		Branch:     e.Branch,
+		LastCommit: e.LastCommit,
+		Size:       e.Size,
+		Kind:       e.Kind,
+		Parent:     e.Parent,
+	}
+}
+
+// repoStamp returns the modification times of the repository's HEAD, index
+// and HEAD reflog, which together change on checkouts, commits, merges,
+// resets and pulls. Missing files are recorded as 0.
+func repoStamp(repoPath string) []int64 {
+	dir := gitDir(repoPath)
+	stamp := make([]int64, 3)
+	for i, name := range []string{"HEAD", "index", filepath.Join("logs", "HEAD")} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			stamp[i] = info.ModTime().UnixNano()
+		}
+	}
+	return stamp
+}
+
+// gitDir returns the git directory of the repository at repoPath, following
+// the "gitdir:" pointer of worktrees and submodules whose .git is a file.
+// Bare repositories are their own git directory.
+func gitDir(repoPath string) string {
+	dotGit := filepath.Join(repoPath, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		if isBareRepository(repoPath) {
+			return repoPath
+		}
+		return dotGit
+	}
+	if info.IsDir() {
+		return dotGit
+	}
+
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return dotGit
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return dotGit
+	}
+	target = strings.TrimSpace(target)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(repoPath, target)
+	}
+	return target
+}
+
+// underAny reports whether path is one of roots or inside one of them.
+func underAny(path string, roots []string) bool {
+	for _, root := range roots {
+		if root, err := filepath.Abs(root); err == nil {
+			if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+	return false
+}
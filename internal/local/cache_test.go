@@ -0,0 +1,75 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanCache(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, name, ".git"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, name, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+	}
+	cachePath := filepath.Join(t.TempDir(), "cache.json")
+
+	cache, err := LoadCache(cachePath)
+	require.NoError(t, err)
+	scanner := NewScanner()
+	scanner.SetCache(cache)
+	repos := collect(scanner.Scan([]string{root}))
+	require.Len(t, repos, 2)
+
+	// The scan saved the cache, so a fresh load serves both repositories
+	cache, err = LoadCache(cachePath)
+	require.NoError(t, err)
+	assert.Len(t, cache.Repositories([]string{root}), 2)
+	assert.Empty(t, cache.Repositories([]string{filepath.Join(root, "other")}))
+
+	aPath := filepath.Join(root, "a")
+	_, _, ok := cache.lookup(aPath)
+	assert.True(t, ok, "unchanged repository should be served from the cache")
+
+	// Moving HEAD invalidates the entry
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(aPath, ".git", "HEAD"), later, later))
+	_, _, ok = cache.lookup(aPath)
+	assert.False(t, ok, "changed repository should be analyzed again")
+
+	// A complete scan drops repositories that no longer exist
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "b")))
+	scanner.SetCache(cache)
+	repos = collect(scanner.Scan([]string{root}))
+	require.Len(t, repos, 1)
+	assert.Equal(t, aPath, repos[0].Path)
+
+	cache, err = LoadCache(cachePath)
+	require.NoError(t, err)
+	assert.Len(t, cache.Repositories([]string{root}), 1)
+}
+
+func TestGitDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".git"), []byte("gitdir: ../main/.git/worktrees/wt\n"), 0o644))
+	assert.Equal(t, filepath.Join(filepath.Dir(root), "main", ".git", "worktrees", "wt"), gitDir(root))
+	assert.Equal(t, filepath.Join(root, "sub", ".git"), gitDir(filepath.Join(root, "sub")))
+}
@@ -0,0 +1,170 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+)
+
+// CopyMode selects how local repositories are copied into the target
+// directory.
+type CopyMode string
+
+const (
+	// CopyClone clones the source, so the copy's origin is the source path
+	CopyClone CopyMode = "clone"
+	// CopyPreserve clones the source and then carries over its branches,
+	// remotes and upstream tracking, so origin points where the source's does
+	CopyPreserve CopyMode = "preserve"
+	// CopyMirror copies the repository directory as it is, including the
+	// working tree, untracked files, stashes and local-only branches
+	CopyMirror CopyMode = "mirror"
+)
+
+// DefaultCopyMode is the copy mode used when none is configured.
+const DefaultCopyMode = CopyClone
+
+// ParseCopyMode validates a copy mode name. An empty name selects
+// DefaultCopyMode.
+func ParseCopyMode(name string) (CopyMode, error) {
+	switch mode := CopyMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return DefaultCopyMode, nil
+	case CopyClone, CopyPreserve, CopyMirror:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid copy mode %q: must be clone, preserve or mirror", name)
+	}
+}
+
+// CopyOptions controls how local repositories are copied.
+type CopyOptions struct {
+	Mode CopyMode
+
+	// Reference borrows objects from the source through git alternates
+	// instead of copying them. The copy then depends on the source's object
+	// store, which must not be deleted or pruned. Mirror copies ignore it.
+	Reference bool
+}
+
+// SetCopyOptions sets how local repositories are copied.
+func (s *Scanner) SetCopyOptions(opts CopyOptions) {
+	s.copyOpts = opts
+}
+
+// preserveRemotes makes the fresh clone at destPath look like the source:
+// every local branch and remote-tracking branch of the source is copied,
+// and when the source has an origin remote its remote and branch
+// configuration replaces the clone's, so origin points at the upstream
+// instead of the source directory. source is the path or URL the clone was
+// made from.
+func preserveRemotes(sourcePath, source, destPath string, opts clone.Options) error {
+	fetch := []string{"fetch", "--quiet", "--prune", "--update-head-ok"}
+	if opts.Depth > 0 {
+		fetch = append(fetch, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Filter != "" {
+		fetch = append(fetch, "--filter="+opts.Filter)
+	}
+	fetch = append(fetch, source)
+	if !opts.SingleBranch {
+		fetch = append(fetch, "+refs/heads/*:refs/heads/*")
+	}
+	fetch = append(fetch, "+refs/remotes/*:refs/remotes/*")
+	if _, err := git(destPath, fetch...); err != nil {
+		return fmt.Errorf("failed to copy branches: %w", err)
+	}
+
+	remotes, err := configEntries(sourcePath, `^remote\.`)
+	if err != nil {
+		return err
+	}
+	hasOrigin := false
+	for _, entry := range remotes {
+		if entry[0] == "remote.origin.url" {
+			hasOrigin = true
+		}
+	}
+	if !hasOrigin {
+		return nil // Keep origin pointing at the source
+	}
+
+	branches, err := configEntries(sourcePath, `^branch\.`)
+	if err != nil {
+		return err
+	}
+
+	// Drop the sections clone wrote before copying the source's
+	sections := []string{"remote.origin"}
+	if branch, err := git(destPath, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		sections = append(sections, "branch."+branch)
+	}
+	for _, section := range sections {
+		_, _ = git(destPath, "config", "--remove-section", section)
+	}
+
+	for _, entry := range append(remotes, branches...) {
+		if _, err := git(destPath, "config", "--add", entry[0], entry[1]); err != nil {
+			return fmt.Errorf("failed to copy remote configuration: %w", err)
+		}
+	}
+	return nil
+}
+
+// configEntries returns the key and value of each local git config entry
+// of the repository whose key matches pattern, in file order.
+func configEntries(repoPath, pattern string) ([][2]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "config", "--local", "--null", "--get-regexp", pattern)
+	output, err := cmd.Output()
+	if err != nil {
+		// git config exits with 1 when no key matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	var entries [][2]string
+	for _, record := range strings.Split(string(output), "\x00") {
+		if record == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(record, "\n")
+		entries = append(entries, [2]string{key, value})
+	}
+	return entries, nil
+}
+
+// git runs a git command in dir and returns its trimmed output. Errors
+// include git's own message.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		errMsg := strings.TrimSpace(string(output))
+		if errMsg != "" {
+			return "", fmt.Errorf("git %s failed: %s", args[0], errMsg)
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+func TestParseCopyMode(t *testing.T) {
+	mode, err := ParseCopyMode("")
+	require.NoError(t, err)
+	assert.Equal(t, CopyClone, mode)
+
+	mode, err = ParseCopyMode("Preserve")
+	require.NoError(t, err)
+	assert.Equal(t, CopyPreserve, mode)
+
+	_, err = ParseCopyMode("move")
+	assert.Error(t, err)
+}
+
+func TestCopyRepoPreserve(t *testing.T) {
+	setupGitEnv(t)
+
+	root := t.TempDir()
+	upstream := filepath.Join(root, "upstream")
+	require.NoError(t, os.MkdirAll(upstream, 0o755))
+	runGit(t, upstream, "init", "--quiet", "--initial-branch=main")
+	runGit(t, upstream, "commit", "--quiet", "--allow-empty", "-m", "one")
+	runGit(t, upstream, "branch", "release")
+
+	source := filepath.Join(root, "src", "app")
+	runGit(t, root, "clone", "--quiet", upstream, source)
+	runGit(t, source, "remote", "add", "fork", "https://example.com/fork/app.git")
+	runGit(t, source, "branch", "topic")
+
+	scanner := NewScanner()
+	scanner.SetCopyOptions(CopyOptions{Mode: CopyPreserve, Reference: true})
+	target := filepath.Join(root, "target")
+	require.NoError(t, scanner.CopyRepo(source, target, clone.Options{}))
+
+	dest := filepath.Join(target, "app")
+	originURL, err := git(dest, "remote", "get-url", "origin")
+	require.NoError(t, err)
+	assert.Equal(t, upstream, originURL)
+
+	forkURL, err := git(dest, "remote", "get-url", "fork")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/fork/app.git", forkURL)
+
+	for _, ref := range []string{"refs/heads/main", "refs/heads/topic", "refs/remotes/origin/release"} {
+		_, err := git(dest, "rev-parse", "--verify", "--quiet", ref)
+		assert.NoError(t, err, "missing %s", ref)
+	}
+
+	upstreamBranch, err := git(dest, "rev-parse", "--abbrev-ref", "main@{upstream}")
+	require.NoError(t, err)
+	assert.Equal(t, "origin/main", upstreamBranch)
+
+	_, err = os.Stat(filepath.Join(dest, ".git", "objects", "info", "alternates"))
+	assert.NoError(t, err, "expected --reference to borrow objects from the source")
+}
+
+func TestCopyRepoMirror(t *testing.T) {
+	setupGitEnv(t)
+
+	root := t.TempDir()
+	source := filepath.Join(root, "src", "app")
+	require.NoError(t, os.MkdirAll(source, 0o755))
+	runGit(t, source, "init", "--quiet", "--initial-branch=main")
+	require.NoError(t, os.WriteFile(filepath.Join(source, "README.md"), []byte("one"), 0o644))
+	runGit(t, source, "add", "README.md")
+	runGit(t, source, "commit", "--quiet", "-m", "one")
+	runGit(t, source, "branch", "topic")
+	require.NoError(t, os.WriteFile(filepath.Join(source, "README.md"), []byte("stashed"), 0o644))
+	runGit(t, source, "stash", "--quiet")
+	require.NoError(t, os.WriteFile(filepath.Join(source, "README.md"), []byte("changed"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(source, "notes.txt"), []byte("wip"), 0o644))
+	require.NoError(t, os.Symlink("README.md", filepath.Join(source, "link")))
+
+	var events []workerpool.Event
+	scanner := NewScanner()
+	scanner.SetCopyOptions(CopyOptions{Mode: CopyMirror})
+	target := filepath.Join(root, "target")
+	scanner.CopyRepos([]Repository{{Name: "app", Path: source}}, target, 1, nil, func(event workerpool.Event) {
+		events = append(events, event)
+	})
+
+	require.Len(t, events, 2)
+	require.NoError(t, events[1].Err)
+	assert.Equal(t, "2 branches, 1 stash, 1 changed file, 2 untracked files", events[1].Detail)
+
+	dest := filepath.Join(target, "app")
+	data, err := os.ReadFile(filepath.Join(dest, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(data))
+	link, err := os.Readlink(filepath.Join(dest, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "README.md", link)
+
+	status := scanner.GetStatus(dest)
+	require.NoError(t, status.Err)
+	assert.Equal(t, "main", status.Branch)
+	assert.Equal(t, 1, status.Stashes)
+}
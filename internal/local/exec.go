@@ -0,0 +1,85 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// ExecResult reports the outcome of running a command in a repository.
+type ExecResult struct {
+	Name     string
+	Path     string
+	Stdout   string
+	Stderr   string
+	ExitCode int // -1 when the command could not be started
+	Duration time.Duration
+	Err      error
+}
+
+// Passed reports whether the command exited successfully.
+func (r ExecResult) Passed() bool {
+	return r.Err == nil
+}
+
+// ExecRepo runs argv with the repository as its working directory,
+// capturing stdout and stderr separately.
+func (s *Scanner) ExecRepo(repo Repository, argv []string) ExecResult {
+	result := ExecResult{Name: repo.Name, Path: repo.Path}
+	if len(argv) == 0 {
+		result.ExitCode = -1
+		result.Err = fmt.Errorf("no command given")
+		return result
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = repo.Path
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	result.Duration = time.Since(start)
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+		result.Err = fmt.Errorf("exit status %d", result.ExitCode)
+	default:
+		result.ExitCode = -1
+		result.Err = fmt.Errorf("failed to run %s: %w", argv[0], err)
+	}
+
+	return result
+}
+
+// ShellCommand returns the argv that runs command through the system shell,
+// for commands typed as a single line.
+func ShellCommand(command string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C", command}
+	}
+	return []string{"sh", "-c", command}
+}
@@ -0,0 +1,48 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecRepo(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	repo := Repository{Name: "repo", Path: dir}
+	scanner := NewScanner()
+
+	result := scanner.ExecRepo(repo, ShellCommand("pwd -P; echo oops >&2"))
+	assert.True(t, result.Passed())
+	assert.Equal(t, 0, result.ExitCode)
+	resolved, _ := filepath.EvalSymlinks(dir)
+	assert.Equal(t, resolved, strings.TrimSpace(result.Stdout))
+	assert.Equal(t, "oops\n", result.Stderr)
+
+	result = scanner.ExecRepo(repo, ShellCommand("exit 3"))
+	assert.False(t, result.Passed())
+	assert.Equal(t, 3, result.ExitCode)
+
+	result = scanner.ExecRepo(repo, []string{"reposync-no-such-command"})
+	assert.False(t, result.Passed())
+	assert.Equal(t, -1, result.ExitCode)
+}
@@ -0,0 +1,167 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFile is the name of the file in a scan root that lists directories
+// to skip, one gitignore-style pattern per line.
+const IgnoreFile = ".reposyncignore"
+
+// DefaultIgnore lists the dependency and build directories that are never
+// searched for repositories unless a pattern negates them, e.g. "!build".
+// Directories that are repositories themselves are still found.
+var DefaultIgnore = []string{
+	"node_modules",
+	"vendor",
+	"__pycache__",
+	"target",
+	"dist",
+	"build",
+}
+
+// IgnoreMatcher matches directory paths against gitignore-style patterns.
+// Patterns without a slash match a directory name at any depth; patterns
+// containing one match paths relative to the scan root. "*" and "?" don't
+// match "/", "**" matches any number of directories, a trailing "/" is
+// ignored and a leading "!" re-includes a directory an earlier pattern
+// excluded. The last matching pattern wins.
+type IgnoreMatcher struct {
+	rules []ignoreRule
+}
+
+// ignoreRule is a compiled ignore pattern.
+type ignoreRule struct {
+	re     *regexp.Regexp
+	negate bool
+}
+
+// NewIgnoreMatcher compiles patterns. Empty lines and lines starting with
+// "#" are skipped.
+func NewIgnoreMatcher(patterns []string) (*IgnoreMatcher, error) {
+	m := &IgnoreMatcher{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		rule := ignoreRule{}
+		if strings.HasPrefix(pattern, "!") {
+			rule.negate = true
+			pattern = pattern[1:]
+		}
+		pattern = strings.TrimSuffix(pattern, "/")
+
+		anchored := strings.Contains(pattern, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+		if pattern == "" {
+			continue
+		}
+
+		expr := globToRegexp(pattern)
+		if anchored {
+			expr = "^" + expr + "$"
+		} else {
+			expr = "(^|/)" + expr + "$"
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+		rule.re = re
+		m.rules = append(m.rules, rule)
+	}
+	return m, nil
+}
+
+// Match reports whether the directory at rel, a slash-separated path
+// relative to the scan root, is ignored.
+func (m *IgnoreMatcher) Match(rel string) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if rule.re.MatchString(rel) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// globToRegexp translates a gitignore-style glob into a regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			b.WriteString("(/.*)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// ReadIgnoreFile reads the patterns in an ignore file. A missing file has
+// no patterns.
+func ReadIgnoreFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read ignore file: %w", err)
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ignore file: %w", err)
+	}
+	return patterns, nil
+}
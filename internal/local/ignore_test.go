@@ -0,0 +1,70 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreMatcher(t *testing.T) {
+	matcher, err := NewIgnoreMatcher([]string{
+		"# dependencies",
+		"node_modules",
+		"build/",
+		"/archive",
+		"clients/*/generated",
+		"**/tmp-*",
+		"scratch/**",
+		"!keep/build",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		rel     string
+		ignored bool
+	}{
+		{"node_modules", true},
+		{"app/node_modules", true},
+		{"node_modules_backup", false},
+		{"build", true},
+		{"app/build", true},
+		{"keep/build", false},
+		{"archive", true},
+		{"old/archive", false},
+		{"clients/go/generated", true},
+		{"clients/go/v2/generated", false},
+		{"tmp-1", true},
+		{"a/b/tmp-2", true},
+		{"scratch/one", true},
+		{"scratch", true},
+		{"src", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			assert.Equal(t, tt.ignored, matcher.Match(tt.rel))
+		})
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	assert.Equal(t, `[^/]*\.go`, globToRegexp("*.go"))
+	assert.Equal(t, `(.*/)?x`, globToRegexp("**/x"))
+	assert.Equal(t, `v[^0-9]`, globToRegexp("v[!0-9]"))
+	assert.Equal(t, `\[x`, globToRegexp("[x"))
+}
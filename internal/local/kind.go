@@ -0,0 +1,133 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RepoKind classifies a repository by how its git directory is laid out.
+type RepoKind int
+
+const (
+	// KindMain is a regular repository with a .git directory, including
+	// the main worktree of repositories with linked worktrees
+	KindMain RepoKind = iota
+	// KindWorktree is a linked worktree created by "git worktree add"
+	KindWorktree
+	// KindBare is a bare repository without a working tree
+	KindBare
+	// KindSubmodule is a submodule checked out inside its superproject
+	KindSubmodule
+)
+
+// String returns the kind's display name.
+func (k RepoKind) String() string {
+	switch k {
+	case KindWorktree:
+		return "worktree"
+	case KindBare:
+		return "bare"
+	case KindSubmodule:
+		return "submodule"
+	default:
+		return "repository"
+	}
+}
+
+// classify determines the kind of the repository at repoPath and, for
+// linked worktrees and submodules, the path of the repository they belong
+// to: the main worktree (or bare repository) and the superproject.
+func classify(repoPath string) (RepoKind, string) {
+	dotGit := filepath.Join(repoPath, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		if isBareRepository(repoPath) {
+			return KindBare, ""
+		}
+		return KindMain, ""
+	}
+	if info.IsDir() {
+		return KindMain, ""
+	}
+
+	dir := gitDir(repoPath)
+
+	// Linked worktrees point at a directory that records the common git
+	// directory they share with the main worktree
+	if data, err := os.ReadFile(filepath.Join(dir, "commondir")); err == nil {
+		common := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(dir, common)
+		}
+		common = filepath.Clean(common)
+		if filepath.Base(common) == ".git" {
+			return KindWorktree, filepath.Dir(common)
+		}
+		return KindWorktree, common
+	}
+
+	// Submodules keep their git directory in the superproject's modules
+	if strings.Contains(filepath.ToSlash(dir), "/modules/") {
+		for parent := filepath.Dir(repoPath); parent != filepath.Dir(parent); parent = filepath.Dir(parent) {
+			if _, err := os.Stat(filepath.Join(parent, ".git")); err == nil {
+				return KindSubmodule, parent
+			}
+		}
+	}
+
+	return KindMain, ""
+}
+
+// isBareRepository reports whether path is the git directory of a bare
+// repository.
+func isBareRepository(path string) bool {
+	if info, err := os.Stat(filepath.Join(path, "HEAD")); err != nil || info.IsDir() {
+		return false
+	}
+	for _, dir := range []string{"objects", "refs"} {
+		if info, err := os.Stat(filepath.Join(path, dir)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
+// submodulePaths returns the checked-out submodules listed in the
+// repository's .gitmodules file.
+func submodulePaths(repoPath string) []string {
+	file, err := os.Open(filepath.Join(repoPath, ".gitmodules"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var paths []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || strings.TrimSpace(key) != "path" {
+			continue
+		}
+		path := filepath.Join(repoPath, filepath.FromSlash(strings.TrimSpace(value)))
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,64 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanClassifiesRepositories(t *testing.T) {
+	setupGitEnv(t)
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	mainRepo := filepath.Join(root, "main")
+	lib := filepath.Join(root, "lib")
+	for _, dir := range []string{mainRepo, lib} {
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		runGit(t, dir, "init", "--quiet", "--initial-branch=main")
+		runGit(t, dir, "commit", "--quiet", "--allow-empty", "-m", "initial")
+	}
+	runGit(t, mainRepo, "worktree", "add", "--quiet", "-b", "feature", filepath.Join(root, "main-feature"))
+	runGit(t, mainRepo, "-c", "protocol.file.allow=always", "submodule", "add", "--quiet", lib, "deps/lib")
+	runGit(t, root, "clone", "--quiet", "--bare", lib, filepath.Join(root, "archive", "lib.git"))
+
+	repos := collect(NewScanner().Scan([]string{root}))
+
+	type classified struct {
+		kind   RepoKind
+		parent string
+	}
+	got := make(map[string]classified)
+	for _, repo := range repos {
+		got[repo.Name] = classified{repo.Kind, repo.Parent}
+	}
+	assert.Equal(t, map[string]classified{
+		"main":            {KindMain, ""},
+		"main-feature":    {KindWorktree, mainRepo},
+		"main/deps/lib":   {KindSubmodule, mainRepo},
+		"lib":             {KindMain, ""},
+		"archive/lib.git": {KindBare, ""},
+	}, got)
+
+	// Workspace commands only operate on working trees they can update
+	paths, err := NewScanner().FindRepositories(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{lib, mainRepo, filepath.Join(root, "main-feature")}, paths)
+}
@@ -0,0 +1,139 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MirrorSummary is what a mirror copy carried over from its source.
+type MirrorSummary struct {
+	Branches  int
+	Stashes   int
+	Changed   int // Tracked files with staged or unstaged changes
+	Untracked int
+}
+
+// String describes the summary, e.g. "3 branches, 1 stash, 2 changed files".
+func (m MirrorSummary) String() string {
+	parts := []string{plural(m.Branches, "branch", "branches")}
+	if m.Stashes > 0 {
+		parts = append(parts, plural(m.Stashes, "stash", "stashes"))
+	}
+	if m.Changed > 0 {
+		parts = append(parts, plural(m.Changed, "changed file", "changed files"))
+	}
+	if m.Untracked > 0 {
+		parts = append(parts, plural(m.Untracked, "untracked file", "untracked files"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// plural formats n with the singular or plural noun.
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
+
+// mirrorRepo copies the repository directory at sourcePath to destPath
+// as it is and checks the copy with git fsck. Linked worktrees and
+// submodules are rejected because their git directory lives outside the
+// directory being copied.
+func (s *Scanner) mirrorRepo(sourcePath, destPath string) (MirrorSummary, error) {
+	if kind, _ := classify(sourcePath); kind == KindWorktree || kind == KindSubmodule {
+		return MirrorSummary{}, fmt.Errorf("cannot mirror a %s: its git directory is outside %s", kind, sourcePath)
+	}
+
+	if err := copyTree(sourcePath, destPath); err != nil {
+		// Don't leave a partial copy behind
+		_ = os.RemoveAll(destPath)
+		return MirrorSummary{}, fmt.Errorf("failed to copy repository: %w", err)
+	}
+
+	// Keep the copy on failure: it holds everything the source had
+	if _, err := git(destPath, "fsck", "--no-progress"); err != nil {
+		return MirrorSummary{}, fmt.Errorf("copied to %s but the integrity check failed: %w", destPath, err)
+	}
+
+	var summary MirrorSummary
+	if branches, err := git(destPath, "for-each-ref", "--format=%(refname)", "refs/heads/"); err == nil && branches != "" {
+		summary.Branches = len(strings.Split(branches, "\n"))
+	}
+	if !isBareRepository(destPath) {
+		status := s.GetStatus(destPath)
+		summary.Stashes, summary.Changed, summary.Untracked = status.Stashes, status.Changed, status.Untracked
+	}
+	return summary, nil
+}
+
+// copyTree recursively copies the directory src to dst, preserving file
+// modes and symbolic links. Other special files are skipped.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		default:
+			return nil // Sockets, pipes and devices
+		}
+	})
+}
+
+// copyFile copies the regular file src to dst with the given permissions.
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,163 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+// PullOutcome describes what PullRepos did with a repository.
+type PullOutcome string
+
+const (
+	// PullUpdated means the branch was fast-forwarded to its upstream.
+	PullUpdated PullOutcome = "updated"
+	// PullUpToDate means the branch already contained its upstream.
+	PullUpToDate PullOutcome = "up to date"
+	// PullSkipped means the repository was fetched but left alone.
+	PullSkipped PullOutcome = "skipped"
+	// PullFailed means fetching or fast-forwarding failed.
+	PullFailed PullOutcome = "failed"
+)
+
+// Reasons a repository is not fast-forwarded.
+const (
+	SkipDirty      = "dirty"
+	SkipDiverged   = "diverged"
+	SkipDetached   = "detached"
+	SkipNoUpstream = "no upstream"
+)
+
+// SkipError reports that a repository was deliberately left unchanged.
+type SkipError struct {
+	Reason string
+}
+
+// Error returns the skip reason.
+func (e *SkipError) Error() string {
+	return "skipped: " + e.Reason
+}
+
+// PullResult reports the outcome of pulling a single repository.
+type PullResult struct {
+	Name    string
+	Path    string
+	Outcome PullOutcome
+	Reason  string // Why the repository was skipped
+	Err     error
+
+	// Duration is the time spent fetching and fast-forwarding
+	Duration time.Duration
+}
+
+// PullRepos fetches every repository, then fast-forwards those that are clean
+// and on a branch tracking an upstream. Repositories that are dirty,
+// detached, diverged or have no upstream are skipped with a reason, so no
+// merge commits are ever created. Both phases use at most jobs workers.
+// progressFn is called from the worker goroutines when a repository starts
+// and finishes; skipped repositories finish with a *SkipError.
+// Results are returned in the order of repos.
+func (s *Scanner) PullRepos(repos []Repository, jobs int, progressFn func(event workerpool.Event)) []PullResult {
+	results := make([]PullResult, len(repos))
+
+	// Fetch everything first so the fast-forward phase sees fresh upstreams
+	workerpool.Run(jobs, len(repos), func(i int) {
+		repo := repos[i]
+		if progressFn != nil {
+			progressFn(workerpool.Started(i, repo.Name))
+		}
+
+		start := time.Now()
+		results[i] = PullResult{Name: repo.Name, Path: repo.Path}
+		if err := s.Fetch(repo.Path); err != nil {
+			results[i].Outcome, results[i].Err = PullFailed, err
+		}
+		results[i].Duration = time.Since(start)
+	})
+
+	workerpool.Run(jobs, len(repos), func(i int) {
+		if results[i].Outcome != PullFailed {
+			start := time.Now()
+			results[i].Outcome, results[i].Reason, results[i].Err = s.fastForward(repos[i].Path)
+			results[i].Duration += time.Since(start)
+		}
+
+		if progressFn != nil {
+			err := results[i].Err
+			if results[i].Outcome == PullSkipped {
+				err = &SkipError{Reason: results[i].Reason}
+			}
+			progressFn(workerpool.Finished(i, repos[i].Name, err))
+		}
+	})
+
+	return results
+}
+
+// Fetch updates the remote-tracking branches of a repository.
+func (s *Scanner) Fetch(repoPath string) error {
+	cmd := exec.Command("git", "-C", repoPath, "fetch", "--quiet")
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		// Include git's error output in the error message
+		errMsg := strings.TrimSpace(string(output))
+		if errMsg != "" {
+			return fmt.Errorf("git fetch failed: %s", errMsg)
+		}
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+
+	return nil
+}
+
+// fastForward merges the upstream into the current branch if that is possible
+// without a merge commit and without touching local changes.
+func (s *Scanner) fastForward(repoPath string) (PullOutcome, string, error) {
+	status := s.GetStatus(repoPath)
+	switch {
+	case status.Err != nil:
+		return PullFailed, "", status.Err
+	case status.Branch == "":
+		return PullSkipped, SkipDetached, nil
+	case status.Upstream == "":
+		return PullSkipped, SkipNoUpstream, nil
+	case status.Behind == 0:
+		return PullUpToDate, "", nil
+	case status.Ahead > 0:
+		return PullSkipped, SkipDiverged, nil
+	case status.IsDirty():
+		return PullSkipped, SkipDirty, nil
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "merge", "--ff-only", "--quiet", "@{upstream}")
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		// Include git's error output in the error message
+		errMsg := strings.TrimSpace(string(output))
+		if errMsg != "" {
+			return PullFailed, "", fmt.Errorf("git merge --ff-only failed: %s", errMsg)
+		}
+		return PullFailed, "", fmt.Errorf("git merge --ff-only failed: %w", err)
+	}
+
+	return PullUpdated, "", nil
+}
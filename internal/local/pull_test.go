@@ -0,0 +1,90 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupGitEnv skips the test without git and sets the identity commits use.
+func setupGitEnv(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "reposync")
+	t.Setenv("GIT_COMMITTER_NAME", "reposync")
+	t.Setenv("GIT_AUTHOR_EMAIL", "reposync@example.com")
+	t.Setenv("GIT_COMMITTER_EMAIL", "reposync@example.com")
+}
+
+// runGit runs a git command in dir, failing the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, output)
+}
+
+func TestPullRepos(t *testing.T) {
+	setupGitEnv(t)
+
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	require.NoError(t, os.MkdirAll(origin, 0o755))
+	runGit(t, origin, "init", "--quiet", "--initial-branch=main")
+	runGit(t, origin, "commit", "--quiet", "--allow-empty", "-m", "one")
+	runGit(t, origin, "commit", "--quiet", "--allow-empty", "-m", "two")
+
+	clones := []string{"behind", "current", "dirty", "diverged", "detached", "noupstream"}
+	var repos []Repository
+	for _, name := range clones {
+		path := filepath.Join(root, name)
+		runGit(t, root, "clone", "--quiet", origin, path)
+		if name != "current" {
+			runGit(t, path, "reset", "--quiet", "--hard", "HEAD~1")
+		}
+		repos = append(repos, Repository{Name: name, Path: path})
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dirty", "notes.txt"), []byte("wip"), 0o644))
+	runGit(t, filepath.Join(root, "diverged"), "commit", "--quiet", "--allow-empty", "-m", "local")
+	runGit(t, filepath.Join(root, "detached"), "checkout", "--quiet", "--detach")
+	runGit(t, filepath.Join(root, "noupstream"), "checkout", "--quiet", "-b", "topic")
+
+	results := NewScanner().PullRepos(repos, 2, nil)
+
+	expected := map[string]struct {
+		outcome PullOutcome
+		reason  string
+	}{
+		"behind":     {PullUpdated, ""},
+		"current":    {PullUpToDate, ""},
+		"dirty":      {PullSkipped, SkipDirty},
+		"diverged":   {PullSkipped, SkipDiverged},
+		"detached":   {PullSkipped, SkipDetached},
+		"noupstream": {PullSkipped, SkipNoUpstream},
+	}
+	require.Len(t, results, len(clones))
+	for _, result := range results {
+		require.NoError(t, result.Err, result.Name)
+		assert.Equal(t, expected[result.Name].outcome, result.Outcome, result.Name)
+		assert.Equal(t, expected[result.Name].reason, result.Reason, result.Name)
+	}
+}
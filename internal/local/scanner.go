@@ -19,83 +19,93 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/layout"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
 )
 
 // Repository represents a local Git repository.
 type Repository struct {
-	Name     string
-	Path     string
-	Size     int64
-	IsGitRepo bool
-	Branch   string
+	Name       string
+	Path       string
+	Size       int64
+	IsGitRepo  bool
+	Branch     string
+	LastCommit time.Time
+	Kind       RepoKind
+	Parent     string // Main worktree of a linked worktree, superproject of a submodule
 }
 
 // Scanner handles local filesystem repository discovery and operations.
-type Scanner struct{}
+type Scanner struct {
+	layout   layout.Layout
+	scanOpts ScanOptions
+	copyOpts CopyOptions
+	cache    *Cache
+}
 
 // NewScanner creates a new local repository scanner.
 func NewScanner() *Scanner {
 	return &Scanner{}
 }
 
-// ScanDirectory recursively scans a directory for Git repositories.
-func (s *Scanner) ScanDirectory(rootPath string) ([]Repository, error) {
-	var repos []Repository
-
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip directories we can't access
-		}
+// SetLayout sets the layout that decides where repositories are copied.
+func (s *Scanner) SetLayout(l layout.Layout) {
+	s.layout = l
+}
 
-		// Skip hidden directories except .git
-		if info.IsDir() && strings.HasPrefix(info.Name(), ".") && info.Name() != ".git" {
-			return filepath.SkipDir
-		}
+// SetCache sets the cache scans reuse repository metadata from. Scans
+// update it and save it to disk once they complete.
+func (s *Scanner) SetCache(c *Cache) {
+	s.cache = c
+}
 
-		// Check if this is a .git directory
-		if info.IsDir() && info.Name() == ".git" {
-			repoPath := filepath.Dir(path)
-			repo, err := s.analyzeRepo(repoPath)
-			if err != nil {
-				return nil // Skip repos we can't analyze
-			}
+// DestPath returns the directory sourcePath is copied into under targetDir.
+// Host and owner come from the origin remote; repositories without one are
+// placed under the "local" host and their parent directory's name.
+func (s *Scanner) DestPath(sourcePath, targetDir string) string {
+	if absPath, err := filepath.Abs(sourcePath); err == nil {
+		sourcePath = absPath
+	}
 
-			repos = append(repos, *repo)
-			return filepath.SkipDir // Don't descend into .git
+	fields := layout.Fields{
+		Host:  layout.LocalHost,
+		Owner: filepath.Base(filepath.Dir(sourcePath)),
+		Repo:  filepath.Base(sourcePath),
+	}
+	if remote, err := s.GetRemoteURL(sourcePath); err == nil {
+		if parsed, ok := layout.ParseRemoteURL(remote); ok {
+			fields.Host = parsed.Host
+			fields.Owner = parsed.Owner
 		}
+	}
+	return s.layout.Path(targetDir, fields)
+}
 
-		return nil
-	})
-
-	if err != nil {
+// ScanDirectory searches a directory for Git repositories in parallel.
+func (s *Scanner) ScanDirectory(rootPath string) ([]Repository, error) {
+	if _, err := os.Stat(rootPath); err != nil {
 		return nil, fmt.Errorf("failed to scan directory: %w", err)
 	}
-
-	return repos, nil
+	return collect(s.Scan([]string{rootPath})), nil
 }
 
 // ScanMultipleDirectories scans multiple directories for repositories.
+// Directories that can't be read are skipped.
 func (s *Scanner) ScanMultipleDirectories(paths []string) ([]Repository, error) {
-	var allRepos []Repository
-
-	for _, path := range paths {
-		repos, err := s.ScanDirectory(path)
-		if err != nil {
-			// Log error but continue with other directories
-			continue
-		}
-		allRepos = append(allRepos, repos...)
-	}
-
-	return allRepos, nil
+	return collect(s.Scan(paths)), nil
 }
 
 // analyzeRepo extracts metadata from a Git repository.
 func (s *Scanner) analyzeRepo(repoPath string) (*Repository, error) {
 	repo := &Repository{
-		Name:     filepath.Base(repoPath),
-		Path:     repoPath,
+		Name:      filepath.Base(repoPath),
+		Path:      repoPath,
 		IsGitRepo: true,
 	}
 
@@ -105,6 +115,12 @@ func (s *Scanner) analyzeRepo(repoPath string) (*Repository, error) {
 		repo.Branch = branch
 	}
 
+	// Get last commit time
+	lastCommit, err := s.getLastCommitTime(repoPath)
+	if err == nil {
+		repo.LastCommit = lastCommit
+	}
+
 	// Get repository size
 	size, err := s.getDirectorySize(repoPath)
 	if err == nil {
@@ -125,17 +141,49 @@ func (s *Scanner) getCurrentBranch(repoPath string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// getDirectorySize calculates the total size of a directory.
+// GetHeadCommit retrieves the full SHA of HEAD.
+func (s *Scanner) GetHeadCommit(repoPath string) (string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// getLastCommitTime retrieves the committer date of HEAD.
+func (s *Scanner) getLastCommitTime(repoPath string) (time.Time, error) {
+	cmd := exec.Command("git", "-C", repoPath, "log", "-1", "--format=%ct")
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, 0), nil
+}
+
+// getDirectorySize calculates the total size of a directory. Dependency and
+// build directories (DefaultIgnore) are skipped, like in the scan itself.
 func (s *Scanner) getDirectorySize(path string) (int64, error) {
 	var size int64
 
-	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+	err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil
 		}
-		if !info.IsDir() {
-			size += info.Size()
+		if info.IsDir() {
+			if filePath != path && slices.Contains(DefaultIgnore, info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
 		}
+		size += info.Size()
 		return nil
 	})
 
@@ -143,48 +191,107 @@ func (s *Scanner) getDirectorySize(path string) (int64, error) {
 }
 
 // CopyRepo copies a Git repository to the target directory.
-func (s *Scanner) CopyRepo(sourcePath, targetDir string) error {
-	repoName := filepath.Base(sourcePath)
-	destPath := filepath.Join(targetDir, repoName)
+func (s *Scanner) CopyRepo(sourcePath, targetDir string, opts clone.Options) error {
+	_, err := s.copyRepo(sourcePath, targetDir, opts)
+	return err
+}
+
+// copyRepo copies a Git repository to the target directory and returns a
+// description of what was carried over, if worth reporting.
+func (s *Scanner) copyRepo(sourcePath, targetDir string, opts clone.Options) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
+
+	destPath := s.DestPath(sourcePath, targetDir)
 
 	// Check if source exists and is a Git repository
-	gitDir := filepath.Join(sourcePath, ".git")
-	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
-		return fmt.Errorf("source is not a Git repository: %s", sourcePath)
+	if !s.IsGitRepository(sourcePath) && !isBareRepository(sourcePath) {
+		return "", fmt.Errorf("source is not a Git repository: %s", sourcePath)
 	}
 
 	// Check if destination already exists
 	if _, err := os.Stat(destPath); err == nil {
-		return fmt.Errorf("destination already exists: %s", destPath)
+		return "", fmt.Errorf("destination already exists: %s", destPath)
+	}
+
+	// Create the parent directories required by the layout
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	// Mirror copies take the directory as it is, so clone options don't apply
+	if s.copyOpts.Mode == CopyMirror {
+		summary, err := s.mirrorRepo(sourcePath, destPath)
+		if err != nil {
+			return "", err
+		}
+		return summary.String(), nil
 	}
 
-	// Create target directory if it doesn't exist
-	if err := os.MkdirAll(targetDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create target directory: %w", err)
+	// Local clones ignore --depth and --filter unless the source is a URL
+	source := sourcePath
+	if opts.NeedsTransport() {
+		absPath, err := filepath.Abs(sourcePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve source path: %w", err)
+		}
+		source = "file://" + filepath.ToSlash(absPath)
 	}
 
 	// Use git clone for proper repository copying
-	cmd := exec.Command("git", "clone", sourcePath, destPath)
+	args := append([]string{"clone"}, opts.Args()...)
+	if s.copyOpts.Reference {
+		args = append(args, "--reference", sourcePath)
+	}
+	args = append(args, source, destPath)
+	cmd := exec.Command("git", args...)
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
 		// Include git's error output in the error message
 		errMsg := strings.TrimSpace(string(output))
 		if errMsg != "" {
-			return fmt.Errorf("git clone failed: %s", errMsg)
+			return "", fmt.Errorf("git clone failed: %s", errMsg)
 		}
-		return fmt.Errorf("git clone failed: %w", err)
+		return "", fmt.Errorf("git clone failed: %w", err)
 	}
 
-	return nil
+	if s.copyOpts.Mode == CopyPreserve {
+		if err := preserveRemotes(sourcePath, source, destPath, opts); err != nil {
+			// Don't leave a half-configured copy behind
+			_ = os.RemoveAll(destPath)
+			return "", err
+		}
+	}
+
+	return "", opts.ApplySparse(destPath)
 }
 
-// CopyRepos copies multiple repositories with progress reporting.
-func (s *Scanner) CopyRepos(repos []Repository, targetDir string, progressFn func(repo string, success bool, err error)) {
-	for _, repo := range repos {
-		err := s.CopyRepo(repo.Path, targetDir)
-		progressFn(repo.Name, err == nil, err)
-	}
+// CopyRepos copies multiple repositories concurrently using at most jobs workers.
+// optsFn selects the clone options for each repository by source path; nil
+// copies everything in full.
+// progressFn is called from the worker goroutines when each copy starts and
+// finishes, so it must be safe for concurrent use.
+func (s *Scanner) CopyRepos(repos []Repository, targetDir string, jobs int, optsFn clone.OptionsFunc, progressFn func(event workerpool.Event)) {
+	workerpool.Run(jobs, len(repos), func(i int) {
+		repo := repos[i]
+		if progressFn != nil {
+			progressFn(workerpool.Started(i, repo.Name))
+		}
+
+		var opts clone.Options
+		if optsFn != nil {
+			opts = optsFn(repo.Path)
+		}
+		detail, err := s.copyRepo(repo.Path, targetDir, opts)
+
+		if progressFn != nil {
+			event := workerpool.Finished(i, repo.Name, err)
+			event.Detail = detail
+			progressFn(event)
+		}
+	})
 }
 
 // IsGitRepository checks if a directory is a Git repository.
@@ -239,6 +346,25 @@ func FormatSize(size int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
 }
 
+// FormatAge formats a timestamp relative to now, e.g. "3d ago".
+func FormatAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/(24*30)))
+	default:
+		return fmt.Sprintf("%dy ago", int(d.Hours()/(24*365)))
+	}
+}
+
 // RefreshRepo performs a git pull on an existing repository.
 func (s *Scanner) RefreshRepo(repoPath string) error {
 	// Verify the directory exists and is a git repository
@@ -0,0 +1,138 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+// Status is a detailed working tree and branch status of a repository.
+type Status struct {
+	Path       string
+	Branch     string // Empty for a detached HEAD
+	Upstream   string // Empty when the branch has no upstream
+	Ahead      int
+	Behind     int
+	Changed    int // Tracked files with staged or unstaged changes
+	Untracked  int
+	Stashes    int
+	LastCommit time.Time
+	Err        error
+}
+
+// IsDirty reports whether the working tree has changed or untracked files.
+func (s Status) IsDirty() bool {
+	return s.Changed > 0 || s.Untracked > 0
+}
+
+// FindRepositories returns the paths of the git repositories in root,
+// including root itself, without descending into them. Unlike ScanDirectory
+// it doesn't collect any metadata, so it is cheap on large trees.
+func (s *Scanner) FindRepositories(root string) ([]string, error) {
+	var (
+		mu    sync.Mutex
+		repos []string
+	)
+	err := s.walk(root, false, func(repoPath string) {
+		mu.Lock()
+		repos = append(repos, repoPath)
+		mu.Unlock()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// GetStatus retrieves the detailed status of a repository.
+func (s *Scanner) GetStatus(repoPath string) Status {
+	status := Status{Path: repoPath}
+
+	cmd := exec.Command("git", "-C", repoPath, "status", "--porcelain=v2", "--branch")
+	output, err := cmd.Output()
+	if err != nil {
+		status.Err = fmt.Errorf("git status failed: %w", err)
+		return status
+	}
+	parsePorcelainStatus(string(output), &status)
+
+	if count, err := countStashes(repoPath); err == nil {
+		status.Stashes = count
+	}
+	if lastCommit, err := s.getLastCommitTime(repoPath); err == nil {
+		status.LastCommit = lastCommit
+	}
+
+	return status
+}
+
+// GetStatuses retrieves the status of every repository using at most jobs
+// workers. Results are returned in the order of repoPaths.
+func (s *Scanner) GetStatuses(repoPaths []string, jobs int) []Status {
+	statuses := make([]Status, len(repoPaths))
+	workerpool.Run(jobs, len(repoPaths), func(i int) {
+		statuses[i] = s.GetStatus(repoPaths[i])
+	})
+	return statuses
+}
+
+// parsePorcelainStatus fills status from `git status --porcelain=v2 --branch`.
+func parsePorcelainStatus(output string, status *Status) {
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				status.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+			if len(fields) == 2 {
+				status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "), strings.HasPrefix(line, "u "):
+			status.Changed++
+		case strings.HasPrefix(line, "? "):
+			status.Untracked++
+		}
+	}
+}
+
+// countStashes returns the number of stash entries.
+func countStashes(repoPath string) (int, error) {
+	cmd := exec.Command("git", "-C", repoPath, "stash", "list")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" {
+		return 0, nil
+	}
+	return len(strings.Split(trimmed, "\n")), nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePorcelainStatus(t *testing.T) {
+	output := `# branch.oid 1234567890abcdef
+# branch.head main
+# branch.upstream origin/main
+# branch.ab +2 -5
+1 .M N... 100644 100644 100644 aaa bbb README.md
+2 R. N... 100644 100644 100644 aaa bbb R100 new.go	old.go
+u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.go
+? notes.txt
+? tmp/
+`
+
+	var status Status
+	parsePorcelainStatus(output, &status)
+
+	assert.Equal(t, Status{
+		Branch:    "main",
+		Upstream:  "origin/main",
+		Ahead:     2,
+		Behind:    5,
+		Changed:   3,
+		Untracked: 2,
+	}, status)
+	assert.True(t, status.IsDirty())
+
+	var detached Status
+	parsePorcelainStatus("# branch.oid abc\n# branch.head (detached)\n", &detached)
+	assert.Equal(t, Status{}, detached)
+	assert.False(t, detached.IsDirty())
+}
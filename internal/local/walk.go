@@ -0,0 +1,282 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ScanOptions controls how directories are searched for repositories.
+type ScanOptions struct {
+	// MaxDepth is how many directory levels below each root are searched;
+	// 0 searches the whole tree
+	MaxDepth int
+
+	// Ignore holds gitignore-style patterns for directories to skip, applied
+	// after DefaultIgnore and before the root's .reposyncignore file
+	Ignore []string
+
+	// Workers is the number of directories read in parallel; 0 uses one
+	// per CPU, with a minimum of 4
+	Workers int
+}
+
+// workers returns the number of parallel walkers to use.
+func (o ScanOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return max(runtime.NumCPU(), 4)
+}
+
+// SetScanOptions sets how directories are searched for repositories.
+func (s *Scanner) SetScanOptions(opts ScanOptions) {
+	s.scanOpts = opts
+}
+
+// ignoreMatchers returns the matcher for the directories below root and the
+// matcher for the user's own patterns alone. Only the user's patterns skip
+// repositories, so a repository named e.g. build is still found.
+func (s *Scanner) ignoreMatchers(root string) (all, user *IgnoreMatcher, err error) {
+	userPatterns := append([]string{}, s.scanOpts.Ignore...)
+	filePatterns, err := ReadIgnoreFile(filepath.Join(root, IgnoreFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	userPatterns = append(userPatterns, filePatterns...)
+
+	if all, err = NewIgnoreMatcher(append(append([]string{}, DefaultIgnore...), userPatterns...)); err != nil {
+		return nil, nil, err
+	}
+	if user, err = NewIgnoreMatcher(userPatterns); err != nil {
+		return nil, nil, err
+	}
+	return all, user, nil
+}
+
+// walkDir is a directory waiting to be read by the walker.
+type walkDir struct {
+	path  string
+	rel   string // Slash-separated path below the root
+	depth int
+}
+
+// walk searches root for git repositories with a pool of parallel workers,
+// calling found with the path of each one. found is called from the worker
+// goroutines, so it must be safe for concurrent use. Repositories are not
+// descended into, and neither are hidden, ignored or too deep directories
+// or symbolic links. Bare repositories and the submodules of the
+// repositories found are only reported when all is set.
+func (s *Scanner) walk(root string, all bool, found func(repoPath string)) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("failed to scan directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("failed to scan directory: %s is not a directory", root)
+	}
+
+	matcher, userMatcher, err := s.ignoreMatchers(root)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu      sync.Mutex
+		cond    = sync.NewCond(&mu)
+		queue   = []walkDir{{path: root}}
+		pending = 1 // Directories queued or being read
+		wg      sync.WaitGroup
+	)
+
+	visit := func(dir walkDir) []walkDir {
+		if s.IsGitRepository(dir.path) {
+			found(dir.path)
+			if all {
+				findSubmodules(dir.path, found)
+			}
+			return nil
+		}
+		if isBareRepository(dir.path) {
+			if all {
+				found(dir.path)
+			}
+			return nil
+		}
+		if s.scanOpts.MaxDepth > 0 && dir.depth >= s.scanOpts.MaxDepth {
+			return nil
+		}
+
+		entries, err := os.ReadDir(dir.path)
+		if err != nil {
+			return nil // Skip directories we can't access
+		}
+
+		var children []walkDir
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			rel := path.Join(dir.rel, entry.Name())
+			childPath := filepath.Join(dir.path, entry.Name())
+			if matcher.Match(rel) {
+				// Default ignores are for dependency and build output, not repositories
+				isRepo := s.IsGitRepository(childPath) || isBareRepository(childPath)
+				if !isRepo || userMatcher.Match(rel) {
+					continue
+				}
+			}
+			children = append(children, walkDir{
+				path:  childPath,
+				rel:   rel,
+				depth: dir.depth + 1,
+			})
+		}
+		return children
+	}
+
+	for range s.scanOpts.workers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				for len(queue) == 0 && pending > 0 {
+					cond.Wait()
+				}
+				if pending == 0 {
+					mu.Unlock()
+					return
+				}
+				dir := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				mu.Unlock()
+
+				children := visit(dir)
+
+				mu.Lock()
+				queue = append(queue, children...)
+				pending += len(children) - 1
+				mu.Unlock()
+				cond.Broadcast()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// findSubmodules calls found with the path of each checked-out submodule of
+// the repository at repoPath, including nested submodules.
+func findSubmodules(repoPath string, found func(repoPath string)) {
+	for _, path := range submodulePaths(repoPath) {
+		found(path)
+		findSubmodules(path, found)
+	}
+}
+
+// foundRepo is a repository found under a scan root.
+type foundRepo struct {
+	root string
+	path string
+}
+
+// Scan searches roots for repositories in parallel and sends each one, with
+// its metadata, on the returned channel as soon as it has been analyzed. The
+// channel is closed once every root has been searched. Roots that can't be
+// read are skipped. Repositories are read from the scanner's cache when they
+// haven't changed, and the cache is saved before the channel is closed.
+func (s *Scanner) Scan(roots []string) <-chan Repository {
+	found := make(chan foundRepo)
+	out := make(chan Repository)
+
+	absRoots := make([]string, 0, len(roots))
+	for _, root := range roots {
+		if abs, err := filepath.Abs(root); err == nil {
+			absRoots = append(absRoots, abs)
+		}
+	}
+
+	go func() {
+		defer close(found)
+		for _, root := range absRoots {
+			_ = s.walk(root, true, func(repoPath string) {
+				found <- foundRepo{root: root, path: repoPath}
+			})
+		}
+	}()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[string]bool)
+	)
+	for range s.scanOpts.workers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range found {
+				mu.Lock()
+				seen[f.path] = true
+				mu.Unlock()
+
+				repo, stamp, cached := s.cache.lookup(f.path)
+				if !cached {
+					analyzed, err := s.analyzeRepo(f.path)
+					if err != nil {
+						continue // Skip repos we can't analyze
+					}
+					repo = *analyzed
+					repo.Kind, repo.Parent = classify(f.path)
+
+					// Name nested repos by their path below the root, so
+					// layouts like {owner}/{repo} don't show duplicate names
+					if rel, err := filepath.Rel(f.root, f.path); err == nil && rel != "." {
+						repo.Name = filepath.ToSlash(rel)
+					}
+					s.cache.store(repo, stamp)
+				}
+				out <- repo
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		s.cache.prune(absRoots, seen)
+		_ = s.cache.Save() // The cache is best-effort
+		close(out)
+	}()
+
+	return out
+}
+
+// collect drains a scan into a slice ordered by path.
+func collect(repos <-chan Repository) []Repository {
+	var all []Repository
+	for repo := range repos {
+		all = append(all, repo)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Path < all[j].Path })
+	return all
+}
@@ -0,0 +1,86 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanDirectory(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"a/.git",
+		"group/b/.git",
+		"group/b/nested/.git", // Inside a repository
+		"deep/one/two/c/.git",
+		"node_modules/pkg/.git",
+		"build/tool/.git",
+		"acme/build/.git", // A repository named like a default ignore
+		"acme/vendor/.git",
+		"dist/site/.git",
+		"skipped/d/.git",
+		".hidden/e/.git",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, IgnoreFile), []byte("# local\nskipped\n!dist\n"), 0o644))
+
+	names := func(repos []Repository) []string {
+		var names []string
+		for _, repo := range repos {
+			names = append(names, repo.Name)
+		}
+		return names
+	}
+
+	scanner := NewScanner()
+	repos, err := scanner.ScanDirectory(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "acme/build", "acme/vendor", "deep/one/two/c", "dist/site", "group/b"}, names(repos))
+
+	scanner.SetScanOptions(ScanOptions{MaxDepth: 2, Ignore: []string{"/a", "acme/vendor"}, Workers: 2})
+	repos, err = scanner.ScanDirectory(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/build", "dist/site", "group/b"}, names(repos), "user patterns still skip repositories")
+
+	paths, err := scanner.FindRepositories(filepath.Join(root, "group", "b"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "group", "b")}, paths)
+
+	_, err = scanner.ScanDirectory(filepath.Join(root, "missing"))
+	assert.Error(t, err)
+}
+
+func TestDirectorySizeSkipsDefaultIgnores(t *testing.T) {
+	repo := t.TempDir()
+	for path, size := range map[string]int{
+		"main.go":                 10,
+		".git/HEAD":               5,
+		"node_modules/pkg/big.js": 1000,
+		"build/app":               1000,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, path), make([]byte, size), 0o644))
+	}
+
+	size, err := NewScanner().getDirectorySize(repo)
+	require.NoError(t, err)
+	assert.Equal(t, int64(15), size)
+}
@@ -0,0 +1,140 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lockfile freezes the repositories in a target directory to exact
+// commits and restores them later.
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+)
+
+// DefaultFile is the lockfile name used when none is given.
+const DefaultFile = "reposync.lock"
+
+// Version is the lockfile format version written by Freeze.
+const Version = 1
+
+// Lockfile records the exact state of every repository in a target directory.
+type Lockfile struct {
+	Version  int       `json:"version"`
+	FrozenAt time.Time `json:"frozen_at"`
+	Repos    []Entry   `json:"repos"`
+}
+
+// Entry pins a single repository.
+type Entry struct {
+	// Path is the repository's slash-separated path inside the target directory
+	Path string `json:"path"`
+
+	// Remote is the origin URL the repository is cloned from
+	Remote string `json:"remote,omitempty"`
+
+	// Branch is the checked out branch; empty for a detached HEAD
+	Branch string `json:"branch,omitempty"`
+
+	// Commit is the full SHA of HEAD
+	Commit string `json:"commit"`
+
+	// Dirty records that the working tree had uncommitted changes, which the
+	// lockfile does not capture
+	Dirty bool `json:"dirty,omitempty"`
+}
+
+// Freeze scans targetDir and records the remote, branch and HEAD commit of
+// every repository in it.
+func Freeze(scanner *local.Scanner, targetDir string) (*Lockfile, error) {
+	repos, err := scanner.ScanDirectory(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lockfile{Version: Version, FrozenAt: time.Now().UTC()}
+	for _, repo := range repos {
+		// Submodules are pinned by their superproject, linked worktrees share
+		// their main repository's objects, and bare repositories would be
+		// restored as working trees
+		if repo.Kind == local.KindSubmodule || repo.Kind == local.KindWorktree || repo.Kind == local.KindBare {
+			continue
+		}
+
+		rel, err := filepath.Rel(targetDir, repo.Path)
+		if err != nil || rel == "." {
+			continue
+		}
+
+		commit, err := scanner.GetHeadCommit(repo.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HEAD of %s: %w", rel, err)
+		}
+
+		entry := Entry{Path: filepath.ToSlash(rel), Commit: commit}
+		if repo.Branch != "HEAD" {
+			entry.Branch = repo.Branch
+		}
+		if remote, err := scanner.GetRemoteURL(repo.Path); err == nil {
+			entry.Remote = remote
+		}
+		if status, err := scanner.GetRepoStatus(repo.Path); err == nil && status != "clean" {
+			entry.Dirty = true
+		}
+
+		lock.Repos = append(lock.Repos, entry)
+	}
+
+	sort.Slice(lock.Repos, func(i, j int) bool {
+		return lock.Repos[i].Path < lock.Repos[j].Path
+	})
+
+	return lock, nil
+}
+
+// Load reads a lockfile from disk.
+func Load(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	var lock Lockfile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile: %w", err)
+	}
+	if lock.Version > Version {
+		return nil, fmt.Errorf("unsupported lockfile version %d", lock.Version)
+	}
+
+	return &lock, nil
+}
+
+// Save writes the lockfile to disk.
+func (l *Lockfile) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+)
+
+// setupGitEnv skips the test without git and sets the identity commits use.
+func setupGitEnv(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "reposync")
+	t.Setenv("GIT_COMMITTER_NAME", "reposync")
+	t.Setenv("GIT_AUTHOR_EMAIL", "reposync@example.com")
+	t.Setenv("GIT_COMMITTER_EMAIL", "reposync@example.com")
+}
+
+// runGit runs a git command in dir, failing the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, output)
+}
+
+func TestFreezeSkipsSubmodulesWorktreesAndBareRepositories(t *testing.T) {
+	setupGitEnv(t)
+
+	root := t.TempDir()
+	lib := filepath.Join(t.TempDir(), "lib")
+	app := filepath.Join(root, "app")
+	for _, dir := range []string{app, lib} {
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		runGit(t, dir, "init", "--quiet", "--initial-branch=main")
+		runGit(t, dir, "commit", "--quiet", "--allow-empty", "-m", "initial")
+	}
+	runGit(t, app, "-c", "protocol.file.allow=always", "submodule", "add", "--quiet", lib, "deps/lib")
+	runGit(t, root, "clone", "--quiet", "--bare", lib, filepath.Join(root, "lib.git"))
+	runGit(t, app, "worktree", "add", "--quiet", "-b", "feature", filepath.Join(root, "app-feature"))
+
+	lock, err := Freeze(local.NewScanner(), root)
+	require.NoError(t, err)
+	require.Len(t, lock.Repos, 1)
+	assert.Equal(t, "app", lock.Repos[0].Path)
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	lock := &Lockfile{
+		Version:  Version,
+		FrozenAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Repos: []Entry{
+			{Path: "acme/api", Remote: "git@github.com:acme/api.git", Branch: "main", Commit: "0123456789abcdef"},
+			{Path: "scratch", Commit: "fedcba9876543210", Dirty: true},
+		},
+	}
+
+	require.NoError(t, lock.Save(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, lock, loaded)
+}
+
+func TestEntryPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{path: "acme/api", expected: filepath.Join("/repos", "acme", "api")},
+		{path: "acme/./api", expected: filepath.Join("/repos", "acme", "api")},
+		{path: "", wantErr: true},
+		{path: "/etc", wantErr: true},
+		{path: "../outside", wantErr: true},
+		{path: "acme/../../outside", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := entryPath(Entry{Path: tt.path}, "/repos")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+// Outcome describes what Restore did with a repository.
+type Outcome string
+
+const (
+	// OutcomeCloned means the repository was missing and has been cloned.
+	OutcomeCloned Outcome = "cloned"
+	// OutcomeCheckedOut means an existing repository was moved to the commit.
+	OutcomeCheckedOut Outcome = "checked out"
+	// OutcomeUnchanged means the repository was already at the commit.
+	OutcomeUnchanged Outcome = "unchanged"
+	// OutcomeFailed means the repository could not be restored.
+	OutcomeFailed Outcome = "failed"
+)
+
+// Result reports the outcome of restoring a single repository.
+type Result struct {
+	Entry   Entry
+	Outcome Outcome
+	Err     error
+}
+
+// Restore clones or checks out every repository in the lockfile inside
+// targetDir using at most jobs workers. Existing repositories with
+// uncommitted changes are left alone and reported as failed.
+// progressFn is called from the worker goroutines, so it must be safe for
+// concurrent use. Results are returned in lockfile order.
+func (l *Lockfile) Restore(targetDir string, jobs int, progressFn func(event workerpool.Event)) []Result {
+	results := make([]Result, len(l.Repos))
+
+	workerpool.Run(jobs, len(l.Repos), func(i int) {
+		entry := l.Repos[i]
+		if progressFn != nil {
+			progressFn(workerpool.Started(i, entry.Path))
+		}
+
+		outcome, err := restoreEntry(entry, targetDir)
+		if err != nil {
+			outcome = OutcomeFailed
+		}
+		results[i] = Result{Entry: entry, Outcome: outcome, Err: err}
+
+		if progressFn != nil {
+			progressFn(workerpool.Finished(i, entry.Path, err))
+		}
+	})
+
+	return results
+}
+
+// restoreEntry brings a single repository to its pinned commit.
+func restoreEntry(entry Entry, targetDir string) (Outcome, error) {
+	repoPath, err := entryPath(entry, targetDir)
+	if err != nil {
+		return OutcomeFailed, err
+	}
+	if entry.Commit == "" {
+		return OutcomeFailed, fmt.Errorf("no commit recorded")
+	}
+
+	outcome := OutcomeCheckedOut
+	if _, err := os.Stat(filepath.Join(repoPath, ".git")); os.IsNotExist(err) {
+		if entry.Remote == "" {
+			return OutcomeFailed, fmt.Errorf("repository is missing and no remote is recorded")
+		}
+		if err := os.MkdirAll(filepath.Dir(repoPath), 0o755); err != nil {
+			return OutcomeFailed, fmt.Errorf("failed to create parent directory: %w", err)
+		}
+		if _, err := git("", "clone", "--no-checkout", entry.Remote, repoPath); err != nil {
+			return OutcomeFailed, err
+		}
+		outcome = OutcomeCloned
+	} else {
+		status, err := git(repoPath, "status", "--porcelain")
+		if err != nil {
+			return OutcomeFailed, err
+		}
+		if status != "" {
+			return OutcomeFailed, fmt.Errorf("working tree has uncommitted changes")
+		}
+		if head, err := git(repoPath, "rev-parse", "HEAD"); err == nil && head == entry.Commit {
+			return OutcomeUnchanged, nil
+		}
+	}
+
+	// Fetch only when the commit isn't available locally
+	if _, err := git(repoPath, "cat-file", "-e", entry.Commit+"^{commit}"); err != nil {
+		if _, err := git(repoPath, "fetch", "origin"); err != nil {
+			return OutcomeFailed, err
+		}
+		if _, err := git(repoPath, "cat-file", "-e", entry.Commit+"^{commit}"); err != nil {
+			return OutcomeFailed, fmt.Errorf("commit %s not found", entry.Commit)
+		}
+	}
+
+	if err := checkout(repoPath, entry, outcome == OutcomeCloned); err != nil {
+		return OutcomeFailed, err
+	}
+	return outcome, nil
+}
+
+// checkout moves HEAD to the pinned commit. The recorded branch is checked
+// out when it points at the commit (or doesn't exist locally yet); otherwise
+// HEAD is detached so no branch is rewritten. In a fresh clone the branch is
+// reset to the commit, as there is no local work to lose.
+func checkout(repoPath string, entry Entry, fresh bool) error {
+	if entry.Branch != "" && fresh {
+		_, err := git(repoPath, "checkout", "-B", entry.Branch, entry.Commit)
+		return err
+	}
+	if entry.Branch != "" {
+		tip, err := git(repoPath, "rev-parse", "--verify", "--quiet", "refs/heads/"+entry.Branch)
+		switch {
+		case err == nil && tip == entry.Commit:
+			_, err := git(repoPath, "checkout", entry.Branch)
+			return err
+		case err != nil:
+			_, err := git(repoPath, "checkout", "-b", entry.Branch, entry.Commit)
+			return err
+		}
+	}
+
+	_, err := git(repoPath, "checkout", "--detach", entry.Commit)
+	return err
+}
+
+// entryPath resolves an entry inside targetDir, rejecting paths that would
+// escape it.
+func entryPath(entry Entry, targetDir string) (string, error) {
+	clean := path.Clean(entry.Path)
+	if entry.Path == "" || path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid repository path %q", entry.Path)
+	}
+	return filepath.Join(targetDir, filepath.FromSlash(clean)), nil
+}
+
+// git runs a git command in dir and returns its trimmed output. Errors
+// include git's own message.
+func git(dir string, args ...string) (string, error) {
+	subcommand := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		errMsg := strings.TrimSpace(string(output))
+		if errMsg != "" {
+			return "", fmt.Errorf("git %s failed: %s", subcommand, errMsg)
+		}
+		return "", fmt.Errorf("git %s failed: %w", subcommand, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package manifest reads reposync.yaml workspace manifests, which declare
+// the repositories a target directory should contain.
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/layout"
+)
+
+// DefaultFile is the manifest file name used when none is given.
+const DefaultFile = "reposync.yaml"
+
+// Manifest declares the desired contents of a workspace.
+type Manifest struct {
+	// TargetDir overrides the configured target directory. Relative paths
+	// are resolved against the manifest's directory.
+	TargetDir string `yaml:"target_dir"`
+
+	// Layout overrides the configured workspace layout
+	Layout string `yaml:"layout"`
+
+	// Protocol overrides the configured clone protocol
+	Protocol string `yaml:"protocol"`
+
+	// Clone holds the clone options applied to every repository
+	Clone clone.Options `yaml:"clone"`
+
+	// Owners lists the users and organizations to sync from
+	Owners []Owner `yaml:"owners"`
+}
+
+// Owner selects repositories from a single GitHub user or organization.
+type Owner struct {
+	// Name is the user or organization login
+	Name string `yaml:"name"`
+
+	// Host is the GitHub host, for owners on GitHub Enterprise Server
+	Host string `yaml:"host"`
+
+	// Repos are glob patterns for repository names; empty selects all
+	Repos []string `yaml:"repos"`
+
+	// Exclude are glob patterns for repository names to leave out
+	Exclude []string `yaml:"exclude"`
+
+	// Clone holds clone options for this owner's repositories
+	Clone clone.Options `yaml:"clone"`
+
+	// Overrides holds clone options for individual repositories by name
+	Overrides map[string]clone.Options `yaml:"overrides"`
+}
+
+// Load reads and validates the manifest at path.
+func Load(filePath string) (*Manifest, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", filePath, err)
+	}
+
+	m.TargetDir = resolvePath(m.TargetDir, filepath.Dir(filePath))
+	return m, nil
+}
+
+// Parse decodes and validates a manifest. Unknown keys are rejected so that
+// typos don't silently change what gets synced.
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks that the manifest can be applied.
+func (m *Manifest) Validate() error {
+	if _, err := layout.Parse(m.Layout); err != nil {
+		return err
+	}
+	if m.Protocol != "" {
+		if _, err := github.ParseProtocol(m.Protocol); err != nil {
+			return err
+		}
+	}
+	if err := m.Clone.Validate(); err != nil {
+		return err
+	}
+
+	if len(m.Owners) == 0 {
+		return fmt.Errorf("no owners listed")
+	}
+
+	seen := make(map[string]bool)
+	for _, owner := range m.Owners {
+		if strings.TrimSpace(owner.Name) == "" {
+			return fmt.Errorf("owner without a name")
+		}
+		if seen[owner.Key()] {
+			return fmt.Errorf("owner %s listed more than once", owner.Name)
+		}
+		seen[owner.Key()] = true
+
+		for _, pattern := range append(append([]string{}, owner.Repos...), owner.Exclude...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("owner %s: invalid pattern %q: %w", owner.Name, pattern, err)
+			}
+		}
+		if err := owner.Clone.Validate(); err != nil {
+			return fmt.Errorf("owner %s: %w", owner.Name, err)
+		}
+		for name, opts := range owner.Overrides {
+			if err := opts.Validate(); err != nil {
+				return fmt.Errorf("owner %s: repository %s: %w", owner.Name, name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Key identifies the owner within the manifest. The same name may be listed
+// once per host.
+func (o Owner) Key() string {
+	return ownerKey(o.Host, o.Name)
+}
+
+// ownerKey returns the case-insensitive key of an owner on a host.
+func ownerKey(host, name string) string {
+	return strings.ToLower(host + "/" + name)
+}
+
+// Matches reports whether the owner selects the named repository: it must
+// match one of Repos (or Repos is empty) and none of Exclude.
+// Names match case-insensitively.
+func (o Owner) Matches(name string) bool {
+	name = strings.ToLower(name)
+
+	included := len(o.Repos) == 0
+	for _, pattern := range o.Repos {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+
+	for _, pattern := range o.Exclude {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// CloneOptionsFor returns the clone options the manifest sets for one of the
+// owner's repositories. Owner options override the manifest-wide options and
+// per-repository overrides override both.
+func (m *Manifest) CloneOptionsFor(owner Owner, repoName string) clone.Options {
+	opts := m.Clone.Merge(owner.Clone)
+	for name, override := range owner.Overrides {
+		if strings.EqualFold(name, repoName) {
+			return opts.Merge(override)
+		}
+	}
+	return opts
+}
+
+// resolvePath expands a leading ~ and makes relative paths relative to dir.
+func resolvePath(p, dir string) string {
+	if p == "" {
+		return ""
+	}
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, p[1:])
+		}
+	}
+	if !filepath.IsAbs(p) {
+		return filepath.Join(dir, p)
+	}
+	return filepath.Clean(p)
+}
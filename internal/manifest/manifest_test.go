@@ -0,0 +1,158 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/layout"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `
+layout: "{owner}/{repo}"
+clone:
+  depth: 1
+owners:
+  - name: acme
+    repos: ["api-*"]
+    exclude: ["*-archive"]
+    overrides:
+      api-monorepo:
+        sparse_paths: [services/api]
+`,
+		},
+		{name: "no owners", data: `layout: "{repo}"`, wantErr: true},
+		{name: "unknown key", data: "owners:\n  - name: acme\n    repo: [api]\n", wantErr: true},
+		{name: "invalid layout", data: "layout: \"{owner}\"\nowners:\n  - name: acme\n", wantErr: true},
+		{name: "invalid pattern", data: "owners:\n  - name: acme\n    repos: [\"[\"]\n", wantErr: true},
+		{name: "duplicate owner", data: "owners:\n  - name: acme\n  - name: ACME\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOwnerMatches(t *testing.T) {
+	owner := Owner{Name: "acme", Repos: []string{"api-*", "web"}, Exclude: []string{"*-archive"}}
+
+	assert.True(t, owner.Matches("api-gateway"))
+	assert.True(t, owner.Matches("Web"), "names match case-insensitively")
+	assert.False(t, owner.Matches("api-archive"), "excludes win over includes")
+	assert.False(t, owner.Matches("docs"))
+	assert.True(t, Owner{Name: "acme"}.Matches("docs"), "no patterns selects everything")
+}
+
+func TestCloneOptionsFor(t *testing.T) {
+	m := &Manifest{Clone: clone.Options{Depth: 1}}
+	owner := Owner{
+		Name:      "acme",
+		Clone:     clone.Options{Filter: clone.FilterBlobless},
+		Overrides: map[string]clone.Options{"monorepo": {SparsePaths: []string{"services/api"}}},
+	}
+
+	assert.Equal(t, clone.Options{Depth: 1, Filter: clone.FilterBlobless}, m.CloneOptionsFor(owner, "api"))
+	assert.Equal(t,
+		clone.Options{Depth: 1, Filter: clone.FilterBlobless, SparsePaths: []string{"services/api"}},
+		m.CloneOptionsFor(owner, "monorepo"))
+}
+
+// fakeSource serves a fixed repository list using an {owner}/{repo} layout,
+// under a directory for the host when one is set.
+type fakeSource struct {
+	host  string
+	repos []github.Repository
+}
+
+func (s fakeSource) ListOwnerRepos(string) ([]github.Repository, error) {
+	return s.repos, nil
+}
+
+func (s fakeSource) RepoPath(targetDir string, repo github.Repository) string {
+	l, _ := layout.Parse("{owner}/{repo}")
+	owner, _ := filepath.Split(repo.FullName)
+	return l.Path(filepath.Join(targetDir, s.host), layout.Fields{Owner: filepath.Clean(owner), Repo: repo.Name})
+}
+
+func TestBuildPlan(t *testing.T) {
+	targetDir := t.TempDir()
+	for _, dir := range []string{"acme/api/.git", "acme/stale/.git", "other/tool/.git"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(targetDir, dir), 0o755))
+	}
+
+	source := fakeSource{repos: []github.Repository{
+		{Name: "web", FullName: "acme/web"},
+		{Name: "api", FullName: "acme/api"},
+		{Name: "api-archive", FullName: "acme/api-archive"},
+		{Name: "fork", FullName: "someone/fork"},
+	}}
+	m := &Manifest{Owners: []Owner{{Name: "acme", Exclude: []string{"*-archive"}}}}
+
+	plan, err := BuildPlan(m, targetDir, func(Owner) (Source, error) { return source, nil })
+	require.NoError(t, err)
+
+	var got []string
+	for _, step := range plan.Steps {
+		rel, _ := filepath.Rel(targetDir, step.Path)
+		got = append(got, string(step.Action)+" "+filepath.ToSlash(rel))
+	}
+	assert.Equal(t, []string{
+		"update acme/api",
+		"clone acme/web",
+		"extra acme/stale",
+		"extra other/tool",
+	}, got)
+}
+
+func TestBuildPlanSameOwnerOnTwoHosts(t *testing.T) {
+	m := &Manifest{Owners: []Owner{{Name: "acme"}, {Name: "ACME", Host: "ghe.example.com"}}}
+	sources := map[string]fakeSource{
+		"":                {repos: []github.Repository{{Name: "web", FullName: "acme/web"}}},
+		"ghe.example.com": {host: "ghe.example.com", repos: []github.Repository{{Name: "api", FullName: "acme/api"}}},
+	}
+	require.NoError(t, m.Validate())
+
+	plan, err := BuildPlan(m, t.TempDir(), func(owner Owner) (Source, error) { return sources[owner.Host], nil })
+	require.NoError(t, err)
+
+	owners := make(map[string]string)
+	for _, step := range plan.Steps {
+		owners[step.Repo.Name] = step.OwnerKey()
+	}
+	assert.Equal(t, map[string]string{"web": m.Owners[0].Key(), "api": m.Owners[1].Key()}, owners)
+	assert.NotEqual(t, m.Owners[0].Key(), m.Owners[1].Key())
+}
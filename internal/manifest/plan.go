@@ -0,0 +1,161 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/local"
+)
+
+// Action is what apply does with a repository.
+type Action string
+
+const (
+	// ActionClone clones a repository missing from the target directory.
+	ActionClone Action = "clone"
+	// ActionUpdate fast-forwards a repository that is already present.
+	ActionUpdate Action = "update"
+	// ActionExtra reports a repository in the target directory that the
+	// manifest doesn't list. Extras are never modified.
+	ActionExtra Action = "extra"
+)
+
+// Step is a single planned change.
+type Step struct {
+	Action Action
+	Owner  string
+	Host   string // Host of the owner as listed in the manifest
+	Repo   github.Repository
+	Path   string
+}
+
+// OwnerKey returns the Key of the manifest owner the step belongs to.
+func (s Step) OwnerKey() string {
+	return ownerKey(s.Host, s.Owner)
+}
+
+// Plan is the ordered list of steps needed to converge a target directory.
+type Plan struct {
+	TargetDir string
+	Steps     []Step
+}
+
+// Source lists an owner's repositories and places them in the workspace.
+// *github.Client implements Source.
+type Source interface {
+	ListOwnerRepos(owner string) ([]github.Repository, error)
+	RepoPath(targetDir string, repo github.Repository) string
+}
+
+// BuildPlan lists the repositories of every owner in the manifest and
+// compares them with the contents of targetDir. sourceFor returns the source
+// to use for an owner.
+func BuildPlan(m *Manifest, targetDir string, sourceFor func(owner Owner) (Source, error)) (*Plan, error) {
+	plan := &Plan{TargetDir: targetDir}
+	managed := make(map[string]string)
+
+	for _, owner := range m.Owners {
+		source, err := sourceFor(owner)
+		if err != nil {
+			return nil, err
+		}
+
+		repos, err := source.ListOwnerRepos(owner.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories for %s: %w", owner.Name, err)
+		}
+		sort.Slice(repos, func(i, j int) bool {
+			return strings.ToLower(repos[i].Name) < strings.ToLower(repos[j].Name)
+		})
+
+		for _, repo := range repos {
+			// Listings for the authenticated user can include repositories
+			// owned by others
+			if !strings.EqualFold(repoOwner(repo), owner.Name) || !owner.Matches(repo.Name) {
+				continue
+			}
+
+			repoPath := source.RepoPath(targetDir, repo)
+			if other, ok := managed[repoPath]; ok {
+				return nil, fmt.Errorf("%s and %s both map to %s; use a layout such as {owner}/{repo}", other, repo.FullName, repoPath)
+			}
+			managed[repoPath] = repo.FullName
+
+			action := ActionClone
+			if _, err := os.Stat(repoPath); err == nil {
+				action = ActionUpdate
+			}
+			plan.Steps = append(plan.Steps, Step{Action: action, Owner: owner.Name, Host: owner.Host, Repo: repo, Path: repoPath})
+		}
+	}
+
+	var extras []string
+	if _, err := os.Stat(targetDir); err == nil {
+		if extras, err = local.NewScanner().FindRepositories(targetDir); err != nil {
+			return nil, err
+		}
+	}
+	for _, repoPath := range extras {
+		if _, ok := managed[repoPath]; !ok && repoPath != targetDir {
+			plan.Steps = append(plan.Steps, Step{Action: ActionExtra, Path: repoPath})
+		}
+	}
+
+	return plan, nil
+}
+
+// Filter returns the steps with the given action.
+func (p *Plan) Filter(action Action) []Step {
+	var steps []Step
+	for _, step := range p.Steps {
+		if step.Action == action {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// Print writes a human-readable summary of the plan to w.
+func (p *Plan) Print(w io.Writer) {
+	for _, step := range p.Steps {
+		rel := step.Path
+		if r, err := filepath.Rel(p.TargetDir, step.Path); err == nil {
+			rel = r
+		}
+
+		switch step.Action {
+		case ActionExtra:
+			fmt.Fprintf(w, "%-6s %s (not in manifest)\n", step.Action, rel)
+		default:
+			fmt.Fprintf(w, "%-6s %s -> %s\n", step.Action, step.Repo.FullName, rel)
+		}
+	}
+
+	fmt.Fprintf(w, "%d to clone, %d to update, %d extra\n",
+		len(p.Filter(ActionClone)), len(p.Filter(ActionUpdate)), len(p.Filter(ActionExtra)))
+}
+
+// repoOwner returns the owner part of a repository's full name.
+func repoOwner(repo github.Repository) string {
+	owner, _, _ := strings.Cut(repo.FullName, "/")
+	return owner
+}
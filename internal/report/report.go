@@ -0,0 +1,254 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report writes per-repository results of batch operations in human
+// or machine-readable formats.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Format selects how results are written.
+type Format string
+
+const (
+	// FormatText streams a line per repository as it starts and finishes.
+	FormatText Format = "text"
+	// FormatTable writes an aligned table once all repositories are done.
+	FormatTable Format = "table"
+	// FormatJSON writes a JSON array of records once all repositories are done.
+	FormatJSON Format = "json"
+	// FormatNDJSON streams one JSON record per line as repositories finish.
+	FormatNDJSON Format = "ndjson"
+)
+
+// ParseFormat validates an output format name. An empty name selects FormatText.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatTable:
+		return FormatTable, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatNDJSON:
+		return FormatNDJSON, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q: must be text, table, json or ndjson", name)
+	}
+}
+
+// Action is what happened to a repository.
+type Action string
+
+const (
+	// ActionCloned means the repository was cloned or copied.
+	ActionCloned Action = "cloned"
+	// ActionSkipped means the repository already existed and was left alone.
+	ActionSkipped Action = "skipped"
+	// ActionRefreshed means the existing repository was pulled.
+	ActionRefreshed Action = "refreshed"
+	// ActionUnchanged means the existing repository was already up to date.
+	ActionUnchanged Action = "unchanged"
+	// ActionFailed means the operation failed.
+	ActionFailed Action = "failed"
+)
+
+// Record is the result for a single repository.
+type Record struct {
+	Name     string
+	Action   Action
+	Path     string
+	Reason   string // Why the repository was skipped
+	Detail   string // What the operation carried over, e.g. for mirror copies
+	Duration time.Duration
+	Err      error
+}
+
+// MarshalJSON encodes the record with its duration in milliseconds and its
+// error as a string.
+func (r Record) MarshalJSON() ([]byte, error) {
+	record := struct {
+		Name       string `json:"name"`
+		Action     Action `json:"action"`
+		Path       string `json:"path,omitempty"`
+		Reason     string `json:"reason,omitempty"`
+		Detail     string `json:"detail,omitempty"`
+		DurationMS int64  `json:"duration_ms"`
+		Error      string `json:"error,omitempty"`
+	}{
+		Name:       r.Name,
+		Action:     r.Action,
+		Path:       r.Path,
+		Reason:     r.Reason,
+		Detail:     r.Detail,
+		DurationMS: r.Duration.Milliseconds(),
+	}
+	if r.Err != nil {
+		record.Error = r.Err.Error()
+	}
+	return json.Marshal(record)
+}
+
+// Verb names the operation in text output.
+type Verb struct {
+	Progressive string // e.g. "Cloning"
+	Past        string // e.g. "cloned"
+}
+
+var (
+	// VerbClone describes cloning from GitHub.
+	VerbClone = Verb{Progressive: "Cloning", Past: "cloned"}
+	// VerbCopy describes copying local repositories.
+	VerbCopy = Verb{Progressive: "Copying", Past: "copied"}
+	// VerbPull describes fetching and fast-forwarding existing repositories.
+	VerbPull = Verb{Progressive: "Pulling", Past: "pulled"}
+)
+
+// Writer collects records and writes them in the selected format. It is safe
+// for concurrent use by worker goroutines.
+type Writer struct {
+	mu      sync.Mutex
+	out     io.Writer
+	errOut  io.Writer
+	format  Format
+	verb    Verb
+	records []Record
+	failed  int
+}
+
+// NewWriter creates a Writer that writes results to out and, in text format,
+// errors to errOut.
+func NewWriter(out, errOut io.Writer, format Format, verb Verb) *Writer {
+	return &Writer{out: out, errOut: errOut, format: format, verb: verb}
+}
+
+// Started reports that work on a repository has begun. Only text output
+// shows it.
+func (w *Writer) Started(name string) {
+	if w.format != FormatText {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprintf(w.out, "%s %s...\n", w.verb.Progressive, name)
+}
+
+// Add records the result for a repository.
+func (w *Writer) Add(r Record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.records = append(w.records, r)
+	if r.Action == ActionFailed {
+		w.failed++
+	}
+
+	switch w.format {
+	case FormatText:
+		switch r.Action {
+		case ActionFailed:
+			fmt.Fprintf(w.errOut, "Error %s %s: %v\n", strings.ToLower(w.verb.Progressive), r.Name, r.Err)
+		case ActionSkipped:
+			reason := r.Reason
+			if reason == "" {
+				reason = "already exists"
+			}
+			fmt.Fprintf(w.out, "Skipped %s: %s\n", r.Name, reason)
+		case ActionRefreshed:
+			fmt.Fprintf(w.out, "Successfully refreshed %s\n", r.Name)
+		case ActionUnchanged:
+			fmt.Fprintf(w.out, "%s is up to date\n", r.Name)
+		default:
+			if r.Detail != "" {
+				fmt.Fprintf(w.out, "Successfully %s %s (%s)\n", w.verb.Past, r.Name, r.Detail)
+				break
+			}
+			fmt.Fprintf(w.out, "Successfully %s %s\n", w.verb.Past, r.Name)
+		}
+	case FormatNDJSON:
+		data, _ := json.Marshal(r)
+		fmt.Fprintf(w.out, "%s\n", data)
+	}
+}
+
+// Flush writes the formats that need every record, i.e. table and JSON.
+// Records are sorted by name so the output doesn't depend on scheduling.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	sort.SliceStable(w.records, func(i, j int) bool {
+		return w.records[i].Name < w.records[j].Name
+	})
+
+	switch w.format {
+	case FormatTable:
+		tw := tabwriter.NewWriter(w.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tACTION\tDURATION\tDETAIL")
+		for _, r := range w.records {
+			errMsg := r.Reason
+			if r.Detail != "" {
+				errMsg = r.Detail
+			}
+			if r.Err != nil {
+				errMsg = r.Err.Error()
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, r.Action, r.Duration.Round(time.Millisecond), errMsg)
+		}
+		return tw.Flush()
+	case FormatJSON:
+		records := w.records
+		if records == nil {
+			records = []Record{}
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		_, err = fmt.Fprintf(w.out, "%s\n", data)
+		return err
+	}
+	return nil
+}
+
+// Note writes a line of information about the whole run, such as the
+// remaining API quota. JSON formats send it to errOut so their output stays
+// parseable.
+func (w *Writer) Note(format string, args ...any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	out := w.out
+	if w.format == FormatJSON || w.format == FormatNDJSON {
+		out = w.errOut
+	}
+	fmt.Fprintf(out, format+"\n", args...)
+}
+
+// Counts returns the number of failed and total records.
+func (w *Writer) Counts() (failed, total int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.failed, len(w.records)
+}
@@ -0,0 +1,102 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	for name, expected := range map[string]Format{"": FormatText, "JSON": FormatJSON, "ndjson": FormatNDJSON, "table": FormatTable} {
+		format, err := ParseFormat(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+
+	_, err := ParseFormat("yaml")
+	assert.Error(t, err)
+}
+
+func TestWriterFormats(t *testing.T) {
+	records := []Record{
+		{Name: "acme/web", Action: ActionFailed, Duration: 2 * time.Second, Err: errors.New("boom")},
+		{Name: "acme/api", Action: ActionCloned, Path: "/repos/api", Duration: 1500 * time.Millisecond},
+	}
+
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{
+			format: FormatNDJSON,
+			expected: `{"name":"acme/web","action":"failed","duration_ms":2000,"error":"boom"}` + "\n" +
+				`{"name":"acme/api","action":"cloned","path":"/repos/api","duration_ms":1500}` + "\n",
+		},
+		{
+			format: FormatJSON,
+			expected: "[\n" +
+				"  {\n    \"name\": \"acme/api\",\n    \"action\": \"cloned\",\n    \"path\": \"/repos/api\",\n    \"duration_ms\": 1500\n  },\n" +
+				"  {\n    \"name\": \"acme/web\",\n    \"action\": \"failed\",\n    \"duration_ms\": 2000,\n    \"error\": \"boom\"\n  }\n" +
+				"]\n",
+		},
+		{
+			format: FormatTable,
+			expected: "NAME      ACTION  DURATION  DETAIL\n" +
+				"acme/api  cloned  1.5s      \n" +
+				"acme/web  failed  2s        boom\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewWriter(&out, &out, tt.format, VerbClone)
+			for _, r := range records {
+				w.Started(r.Name)
+				w.Add(r)
+			}
+			require.NoError(t, w.Flush())
+
+			assert.Equal(t, tt.expected, out.String())
+			failed, total := w.Counts()
+			assert.Equal(t, 1, failed)
+			assert.Equal(t, 2, total)
+		})
+	}
+}
+
+func TestWriterNote(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatTable, FormatJSON, FormatNDJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			w := NewWriter(&out, &errOut, format, VerbClone)
+			w.Note("GitHub API quota: %d", 42)
+
+			machine := format == FormatJSON || format == FormatNDJSON
+			written := &out
+			if machine {
+				written = &errOut
+			}
+			assert.Equal(t, "GitHub API quota: 42\n", written.String())
+			assert.Equal(t, out.Len()+errOut.Len(), written.Len(), "written once")
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// checksum hashes content the way the template source identifies files:
+// git blob SHAs for GitHub templates, whose tree lists them, and SHA-256 for
+// local ones.
+func (e *SyncEngine) checksum(content []byte) string {
+	if e.isLocal {
+		sum := sha256.Sum256(content)
+		return hex.EncodeToString(sum[:])
+	}
+	return gitBlobSHA(content)
+}
+
+// gitBlobSHA returns the object ID git gives content stored as a blob.
+func gitBlobSHA(content []byte) string {
+	h := sha1.New()
+	h.Write([]byte("blob " + strconv.Itoa(len(content)) + "\x00"))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// templateChecksum returns the checksum of a template file. GitHub
+// templates take it from the repository tree, so the file isn't downloaded.
+func (e *SyncEngine) templateChecksum(filePath string) (string, error) {
+	if e.isLocal {
+		sourcePath := filepath.Join(e.localTemplatePath, filePath)
+		content, err := os.ReadFile(sourcePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
+		}
+		return e.checksum(content), nil
+	}
+
+	if e.blobSHAs == nil {
+		if err := e.loadTree(); err != nil {
+			return "", err
+		}
+	}
+	sha, ok := e.blobSHAs[filePath]
+	if !ok {
+		return "", fmt.Errorf("file not found in template: %s", filePath)
+	}
+	return sha, nil
+}
+
+// loadTree caches the blob SHAs of the GitHub template's files.
+func (e *SyncEngine) loadTree() error {
+	tree, err := e.githubClient.GetRepoTree(e.templateOwner, e.templateRepo, e.templateBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list template files: %w", err)
+	}
+	e.blobSHAs = make(map[string]string, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		if entry.Type == "blob" {
+			e.blobSHAs[entry.Path] = entry.SHA
+		}
+	}
+	return nil
+}
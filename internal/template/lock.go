@@ -0,0 +1,204 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LockFile is where a target records the template files synced into it,
+// relative to the target's root.
+const LockFile = ".reposync/template.lock"
+
+// LockVersion is the template lock format version written by syncs.
+const LockVersion = 1
+
+// Lock records which template version each synced file of a target came
+// from, so drift from the template can be detected later.
+type Lock struct {
+	Version int          `json:"version"`
+	Files   []LockedFile `json:"files"`
+}
+
+// LockedFile records the last sync of a single file.
+type LockedFile struct {
+	// Path is the file's slash-separated path inside the target
+	Path string `json:"path"`
+
+	// Source is the template, as owner/repo or a local directory
+	Source string `json:"source"`
+
+	// Ref is the branch, tag or commit of a GitHub template
+	Ref string `json:"ref,omitempty"`
+
+	// Commit is the full SHA of the template commit; empty for local
+	// templates outside Git
+	Commit string `json:"commit,omitempty"`
+
+	// Blob is the git blob SHA of the synced content
+	Blob string `json:"blob"`
+
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// sameVersion reports whether both entries record the same template version
+// of a file, ignoring when it was synced.
+func (f LockedFile) sameVersion(other LockedFile) bool {
+	return f.Source == other.Source && f.Ref == other.Ref &&
+		f.Commit == other.Commit && f.Blob == other.Blob
+}
+
+// LoadLock reads the template lock of a target. A target without one gets
+// an empty lock.
+func LoadLock(targetRepo string) (*Lock, error) {
+	data, err := os.ReadFile(filepath.Join(targetRepo, LockFile))
+	if os.IsNotExist(err) {
+		return &Lock{Version: LockVersion}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template lock: %w", err)
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse template lock: %w", err)
+	}
+	if lock.Version > LockVersion {
+		return nil, fmt.Errorf("unsupported template lock version %d", lock.Version)
+	}
+
+	return &lock, nil
+}
+
+// Save writes the lock into the target.
+func (l *Lock) Save(targetRepo string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal template lock: %w", err)
+	}
+
+	if err := writeFile(filepath.Join(targetRepo, LockFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write template lock: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the entry of the file at path.
+func (l *Lock) Get(path string) (LockedFile, bool) {
+	for _, file := range l.Files {
+		if file.Path == path {
+			return file, true
+		}
+	}
+	return LockedFile{}, false
+}
+
+// Set adds or replaces the entry of a file, keeping entries sorted by path.
+func (l *Lock) Set(file LockedFile) {
+	for i := range l.Files {
+		if l.Files[i].Path == file.Path {
+			l.Files[i] = file
+			return
+		}
+	}
+	l.Files = append(l.Files, file)
+	sort.Slice(l.Files, func(i, j int) bool {
+		return l.Files[i].Path < l.Files[j].Path
+	})
+}
+
+// Source returns the template source recorded in locks: owner/repo for
+// GitHub templates, the directory for local ones.
+func (e *SyncEngine) Source() string {
+	if e.isLocal {
+		return e.localTemplatePath
+	}
+	return e.templateOwner + "/" + e.templateRepo
+}
+
+// templateCommit returns the commit the template is synced from. It is
+// resolved once; local templates outside Git and failed lookups have none.
+func (e *SyncEngine) templateCommit() string {
+	if e.commitResolved {
+		return e.commit
+	}
+	e.commitResolved = true
+
+	if e.isLocal {
+		cmd := exec.Command("git", "-C", e.localTemplatePath, "rev-parse", "HEAD")
+		if output, err := cmd.Output(); err == nil {
+			e.commit = strings.TrimSpace(string(output))
+		}
+	} else if sha, err := e.githubClient.GetCommitSHA(e.templateOwner, e.templateRepo, e.templateBranch); err == nil {
+		e.commit = sha
+	}
+	return e.commit
+}
+
+// recordSync records the changes that were written or already matched the
+// template in the lock of their target. Locks whose entries are all current
+// are left untouched. Failing to save a lock is reported
+// as a failed result for the lock file.
+func (e *SyncEngine) recordSync(changes []Change, results []SyncResult) []SyncResult {
+	locked := make(map[string][]LockedFile)
+	var targets []string
+	now := time.Now().UTC()
+	for i, result := range results {
+		if result.Error != nil || result.Skipped || changes[i].blob == "" {
+			continue
+		}
+		if _, ok := locked[result.TargetRepo]; !ok {
+			targets = append(targets, result.TargetRepo)
+		}
+		locked[result.TargetRepo] = append(locked[result.TargetRepo], LockedFile{
+			Path:     changes[i].FilePath,
+			Source:   e.Source(),
+			Ref:      e.templateBranch,
+			Commit:   e.templateCommit(),
+			Blob:     changes[i].blob,
+			SyncedAt: now,
+		})
+	}
+
+	for _, target := range targets {
+		lock, err := LoadLock(target)
+		if err == nil {
+			changed := false
+			for _, file := range locked[target] {
+				// Keep the sync time of files that still match their entry
+				if entry, ok := lock.Get(file.Path); ok && entry.sameVersion(file) {
+					continue
+				}
+				lock.Set(file)
+				changed = true
+			}
+			if changed {
+				err = lock.Save(target)
+			}
+		}
+		if err != nil {
+			results = append(results, SyncResult{FilePath: LockFile, TargetRepo: target, Error: err})
+		}
+	}
+	return results
+}
@@ -0,0 +1,82 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockAndDrift(t *testing.T) {
+	root := t.TempDir()
+	tmpl := filepath.Join(root, "template")
+	target := filepath.Join(root, "target")
+	writeFiles(t, tmpl, map[string]string{
+		"LICENSE":  "MIT\n",
+		"Makefile": "build:\n",
+		"ci.yml":   "on: push\n",
+		"VERSION":  "1\n",
+	})
+	require.NoError(t, os.MkdirAll(target, 0o755))
+
+	engine := NewLocalSyncEngine(tmpl)
+	files := []string{"LICENSE", "Makefile", "VERSION", "ci.yml"}
+	changes, err := engine.Plan(files, []string{target}, nil)
+	require.NoError(t, err)
+	engine.ApplyChanges(changes, nil, nil)
+
+	lock, err := LoadLock(target)
+	require.NoError(t, err)
+	require.Len(t, lock.Files, 4)
+	license, ok := lock.Get("LICENSE")
+	require.True(t, ok)
+	assert.Equal(t, tmpl, license.Source)
+	assert.Equal(t, "a22a2da24d1ceeef3d0c2f1f4f68923f55b8d4cc", license.Blob, "blob SHA as reported by git hash-object")
+
+	// Syncing the same files again leaves the lock alone
+	before, err := os.ReadFile(filepath.Join(target, LockFile))
+	require.NoError(t, err)
+	changes, err = engine.Plan(files, []string{target}, nil)
+	require.NoError(t, err)
+	engine.ApplyChanges(changes, nil, nil)
+	after, err := os.ReadFile(filepath.Join(target, LockFile))
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+
+	// Change the template and the target on different files
+	writeFiles(t, tmpl, map[string]string{"Makefile": "build: all\n", "ci.yml": "on: pull_request\n"})
+	writeFiles(t, target, map[string]string{"LICENSE": "Apache-2.0\n", "ci.yml": "on: [push]\n"})
+	require.NoError(t, os.Remove(filepath.Join(tmpl, "VERSION")))
+
+	statuses := CheckDrift([]string{target, root}, func(source, ref string) (*SyncEngine, error) {
+		return NewLocalSyncEngine(source), nil
+	})
+	states := make(map[string]DriftState)
+	for _, status := range statuses {
+		require.NoError(t, status.Err)
+		states[status.Path] = status.State
+	}
+	assert.Equal(t, map[string]DriftState{
+		"LICENSE":  DriftLocal,
+		"Makefile": DriftUpstream,
+		"VERSION":  DriftUpstream,
+		"ci.yml":   DriftBoth,
+	}, states, "targets without a lock have no statuses")
+	assert.Equal(t, "removed from the template", statuses[2].Detail)
+}
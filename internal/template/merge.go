@@ -0,0 +1,150 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MergeOutcome is how a template change was merged into a target file.
+type MergeOutcome int
+
+const (
+	// MergeNone means the change isn't a merge.
+	MergeNone MergeOutcome = iota
+	// MergeClean means only the template changed since the last sync, so
+	// the target takes the template's version.
+	MergeClean
+	// MergeMerged means both sides changed and were combined without
+	// conflicts.
+	MergeMerged
+	// MergeConflicted means both sides changed the same lines, which are
+	// marked with conflict markers.
+	MergeConflicted
+)
+
+// String returns the name of the outcome.
+func (o MergeOutcome) String() string {
+	switch o {
+	case MergeClean:
+		return "clean"
+	case MergeMerged:
+		return "merged"
+	case MergeConflicted:
+		return "conflicted"
+	default:
+		return ""
+	}
+}
+
+// planMerge turns the update of a file recorded in the target's lock into
+// a three-way merge, using the version synced last time as the base. Files
+// only changed locally are skipped so the local changes are kept. It
+// reports whether the change was replaced; without a usable base it stays
+// an update.
+func (e *SyncEngine) planMerge(change *Change, existing []byte, locked LockedFile) bool {
+	switch locked.Blob {
+	case gitBlobSHA(existing):
+		change.Action, change.Merge = PlanMerge, MergeClean
+		change.Diff = unifiedDiff(change.FilePath, existing, change.content, true)
+		return true
+	case change.blob:
+		change.Action, change.Reason = PlanSkip, "modified locally, and the template hasn't changed since the last sync"
+		change.Diff = ""
+		return true
+	}
+
+	base, err := e.readBlob(locked.Blob)
+	if err != nil || isBinary(base) || isBinary(existing) || isBinary(change.content) {
+		return false
+	}
+	merged, conflicts, err := mergeFile(existing, base, change.content)
+	if err != nil {
+		return false
+	}
+
+	change.Action, change.Merge = PlanMerge, MergeMerged
+	if conflicts > 0 {
+		change.Merge = MergeConflicted
+	}
+	change.content = merged
+	change.Diff = unifiedDiff(change.FilePath, existing, merged, true)
+	return true
+}
+
+// readBlob reads a blob of the template by its git SHA. Local templates
+// need the blob in their Git repository.
+func (e *SyncEngine) readBlob(sha string) ([]byte, error) {
+	if !e.isLocal {
+		return e.githubClient.GetBlob(e.templateOwner, e.templateRepo, sha)
+	}
+
+	cmd := exec.Command("git", "-C", e.localTemplatePath, "cat-file", "blob", sha)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", sha, err)
+	}
+	return output, nil
+}
+
+// mergeFile merges the changes from base to theirs into ours with git
+// merge-file, returning the result and the number of conflicts.
+func mergeFile(ours, base, theirs []byte) ([]byte, int, error) {
+	dir, err := os.MkdirTemp("", "reposync-merge-")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create merge directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	paths := make([]string, 3)
+	for i, content := range [][]byte{ours, base, theirs} {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("%d", i))
+		if err := os.WriteFile(paths[i], content, 0o600); err != nil {
+			return nil, 0, fmt.Errorf("failed to write merge input: %w", err)
+		}
+	}
+
+	args := append([]string{"merge-file", "-p", "-L", "local", "-L", "last sync", "-L", "template"}, paths...)
+	cmd := exec.Command("git", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+
+	// The exit code is the number of conflicts; errors are negative
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return output, exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("git merge-file failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return output, 0, nil
+}
+
+// SummarizeMerges counts the written merges by outcome.
+func SummarizeMerges(results []SyncResult) map[MergeOutcome]int {
+	counts := make(map[MergeOutcome]int)
+	for _, result := range results {
+		if result.Merge != MergeNone {
+			counts[result.Merge]++
+		}
+	}
+	return counts
+}
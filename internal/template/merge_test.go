@@ -0,0 +1,114 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupGitEnv skips the test without git and sets the identity commits use.
+func setupGitEnv(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "reposync")
+	t.Setenv("GIT_COMMITTER_NAME", "reposync")
+	t.Setenv("GIT_AUTHOR_EMAIL", "reposync@example.com")
+	t.Setenv("GIT_COMMITTER_EMAIL", "reposync@example.com")
+}
+
+// runGit runs a git command in dir, failing the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, output)
+}
+
+func TestPlanMerge(t *testing.T) {
+	setupGitEnv(t)
+
+	root := t.TempDir()
+	tmpl := filepath.Join(root, "template")
+	target := filepath.Join(root, "target")
+	files := []string{"Makefile", "ci.yml", "clean.txt", "keep.txt"}
+	writeFiles(t, tmpl, map[string]string{
+		"Makefile":  "a\nb\nc\nd\ne\nf\ng\n",
+		"ci.yml":    "one\ntwo\n",
+		"clean.txt": "x\n",
+		"keep.txt":  "k\n",
+	})
+	runGit(t, tmpl, "init", "-q")
+	runGit(t, tmpl, "add", ".")
+	runGit(t, tmpl, "commit", "-q", "-m", "base")
+	require.NoError(t, os.MkdirAll(target, 0o755))
+
+	engine := NewLocalSyncEngine(tmpl)
+	changes, err := engine.Plan(files, []string{target}, nil)
+	require.NoError(t, err)
+	engine.ApplyChanges(changes, nil, nil)
+
+	// Change both sides, then sync again
+	writeFiles(t, target, map[string]string{
+		"Makefile": "a\nB\nc\nd\ne\nf\ng\n",
+		"ci.yml":   "one\nlocal\n",
+		"keep.txt": "local\n",
+	})
+	writeFiles(t, tmpl, map[string]string{
+		"Makefile":  "a\nb\nc\nd\ne\nf\nG\n",
+		"ci.yml":    "one\ntemplate\n",
+		"clean.txt": "y\n",
+	})
+	runGit(t, tmpl, "commit", "-q", "-a", "-m", "update")
+
+	engine = NewLocalSyncEngine(tmpl)
+	changes, err = engine.Plan(files, []string{target}, nil)
+	require.NoError(t, err)
+	outcomes := make(map[string]string)
+	for _, change := range changes {
+		outcomes[change.FilePath] = change.Action.String() + " " + change.Merge.String()
+	}
+	assert.Equal(t, map[string]string{
+		"Makefile":  "merge merged",
+		"ci.yml":    "merge conflicted",
+		"clean.txt": "merge clean",
+		"keep.txt":  "skip ",
+	}, outcomes)
+
+	results := engine.ApplyChanges(changes, nil, nil)
+	assert.Equal(t, map[MergeOutcome]int{MergeClean: 1, MergeMerged: 1, MergeConflicted: 1}, SummarizeMerges(results))
+
+	data, err := os.ReadFile(filepath.Join(target, "Makefile"))
+	require.NoError(t, err)
+	assert.Equal(t, "a\nB\nc\nd\ne\nf\nG\n", string(data))
+	data, err = os.ReadFile(filepath.Join(target, "ci.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "one\n<<<<<<< local\nlocal\n=======\ntemplate\n>>>>>>> template\n", string(data))
+	data, err = os.ReadFile(filepath.Join(target, "keep.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "local\n", string(data))
+
+	// The next merge is based on the template version merged this time
+	lock, err := LoadLock(target)
+	require.NoError(t, err)
+	locked, _ := lock.Get("ci.yml")
+	assert.Equal(t, gitBlobSHA([]byte("one\ntemplate\n")), locked.Blob)
+}
@@ -0,0 +1,412 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// PlanAction is what syncing a template file would do to a target.
+type PlanAction int
+
+const (
+	// PlanCreate writes a file that doesn't exist in the target yet.
+	PlanCreate PlanAction = iota
+	// PlanUpdate overwrites a target file whose content differs.
+	PlanUpdate
+	// PlanIdentical leaves a target file that already matches the template.
+	PlanIdentical
+	// PlanSkip leaves a target path that can't be synced, e.g. a directory.
+	PlanSkip
+	// PlanMerge merges template changes into a target file that changed
+	// since the last sync.
+	PlanMerge
+)
+
+// String returns the name of the action.
+func (a PlanAction) String() string {
+	switch a {
+	case PlanCreate:
+		return "create"
+	case PlanUpdate:
+		return "update"
+	case PlanIdentical:
+		return "identical"
+	case PlanSkip:
+		return "skip"
+	case PlanMerge:
+		return "merge"
+	default:
+		return "unknown"
+	}
+}
+
+// IsChange reports whether the action writes to the target.
+func (a PlanAction) IsChange() bool {
+	return a == PlanCreate || a == PlanUpdate || a == PlanMerge
+}
+
+// Change is the planned action for one template file in one target.
+type Change struct {
+	FilePath   string
+	TargetRepo string
+	Action     PlanAction
+	Reason     string // Why the file is skipped
+	Diff       string // Unified diff of the target file against the template
+	Merge      MergeOutcome
+
+	content []byte
+	mode    fs.FileMode
+	blob    string // Git blob SHA of the template file, recorded in the target's lock
+}
+
+// templateFile is the content and permissions of a template file.
+type templateFile struct {
+	content []byte
+	mode    fs.FileMode
+}
+
+// Plan computes what syncing files into targets would do without writing
+// anything. Target files are compared with the template by checksum, and
+// each template file is read at most once, when a target needs its content.
+// Files recorded in a target's lock as synced from this template are merged
+// with the version synced last time as the base, see planMerge. progressFn
+// is called for each target file that is compared. Changes are ordered by
+// target, then by file.
+func (e *SyncEngine) Plan(files, targets []string, progressFn func(progress SyncProgress)) ([]Change, error) {
+	checksums := make(map[string]string, len(files))
+	for _, filePath := range files {
+		checksum, err := e.templateChecksum(filePath)
+		if err != nil {
+			return nil, err
+		}
+		checksums[filePath] = checksum
+	}
+
+	sources := make(map[string]templateFile, len(files))
+	source := func(filePath string) (templateFile, error) {
+		if file, ok := sources[filePath]; ok {
+			return file, nil
+		}
+		file, err := e.readTemplateFile(filePath)
+		if err != nil {
+			return templateFile{}, err
+		}
+		sources[filePath] = file
+		return file, nil
+	}
+
+	changes := make([]Change, 0, len(files)*len(targets))
+	total := len(files) * len(targets)
+	for _, targetRepo := range targets {
+		// A lock that can't be read only means there is nothing to merge with
+		lock, err := LoadLock(targetRepo)
+		if err != nil {
+			lock = &Lock{}
+		}
+
+		for _, filePath := range files {
+			if progressFn != nil {
+				progressFn(SyncProgress{
+					Current:     len(changes) + 1,
+					Total:       total,
+					CurrentFile: filePath,
+					TargetRepo:  targetRepo,
+				})
+			}
+			locked, ok := lock.Get(filePath)
+			if !ok || locked.Source != e.Source() {
+				locked = LockedFile{}
+			}
+			change, err := e.planFile(filePath, targetRepo, checksums[filePath], locked, source)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// planFile compares a template file with its counterpart in the target.
+// locked is the file's lock entry, if it was synced from this template
+// before. source loads the template file once its content is needed.
+func (e *SyncEngine) planFile(filePath, targetRepo, checksum string, locked LockedFile, source func(filePath string) (templateFile, error)) (Change, error) {
+	change := Change{
+		FilePath:   filePath,
+		TargetRepo: targetRepo,
+	}
+
+	destPath := filepath.Join(targetRepo, filePath)
+	info, err := os.Lstat(destPath)
+	var existing []byte
+	switch {
+	case filePath == LockFile:
+		change.Action, change.Reason = PlanSkip, "the template lock is written by reposync"
+		return change, nil
+	case os.IsNotExist(err):
+		change.Action = PlanCreate
+	case err != nil:
+		change.Action, change.Reason = PlanSkip, err.Error()
+		return change, nil
+	case info.IsDir():
+		change.Action, change.Reason = PlanSkip, "destination is a directory"
+		return change, nil
+	case !info.Mode().IsRegular():
+		change.Action, change.Reason = PlanSkip, "destination is not a regular file"
+		return change, nil
+	default:
+		existing, err = os.ReadFile(destPath)
+		if err != nil {
+			change.Action, change.Reason = PlanSkip, err.Error()
+			return change, nil
+		}
+		if e.checksum(existing) == checksum {
+			change.Action = PlanIdentical
+			change.blob = gitBlobSHA(existing)
+			return change, nil
+		}
+		change.Action = PlanUpdate
+	}
+
+	file, err := source(filePath)
+	if err != nil {
+		return Change{}, err
+	}
+	change.content, change.mode = file.content, file.mode
+	change.blob = gitBlobSHA(file.content)
+	if change.Action == PlanUpdate && locked.Blob != "" && e.planMerge(&change, existing, locked) {
+		return change, nil
+	}
+	change.Diff = unifiedDiff(filePath, existing, file.content, change.Action == PlanUpdate)
+	return change, nil
+}
+
+// ApplyChanges writes the create, update and merge changes to their targets.
+// Identical changes are reported as unchanged and skipped changes as
+// skipped, both without touching the target. Updates overwrite existing
+// files, so they are treated as conflicts and resolved by the batch flags,
+// or else by conflictFn, which defaults to skipping. Merges keep the
+// local changes, so they are written without asking. progressFn is
+// called for each change. Afterwards the lock of each target records the
+// files that were written or already matched the template.
+func (e *SyncEngine) ApplyChanges(
+	changes []Change,
+	progressFn func(progress SyncProgress),
+	conflictFn func(conflict ConflictInfo) ConflictAction,
+) []SyncResult {
+	results := make([]SyncResult, 0, len(changes))
+	for i, change := range changes {
+		if progressFn != nil {
+			progressFn(SyncProgress{
+				Current:     i + 1,
+				Total:       len(changes),
+				CurrentFile: change.FilePath,
+				TargetRepo:  change.TargetRepo,
+			})
+		}
+
+		result := SyncResult{
+			FilePath:   change.FilePath,
+			TargetRepo: change.TargetRepo,
+			Success:    true,
+		}
+		if change.Action == PlanIdentical {
+			result.Unchanged = true
+		} else if !change.Action.IsChange() || (change.Action == PlanUpdate && e.resolveConflict(change, conflictFn) == ActionSkip) {
+			result.Skipped = true
+		} else if err := writeFile(filepath.Join(change.TargetRepo, change.FilePath), change.content, change.mode); err != nil {
+			result.Success, result.Error = false, err
+		} else {
+			result.Merge = change.Merge
+		}
+		results = append(results, result)
+	}
+	return e.recordSync(changes, results)
+}
+
+// resolveConflict decides whether an update overwrites the existing file,
+// returning ActionOverwrite or ActionSkip.
+func (e *SyncEngine) resolveConflict(change Change, conflictFn func(conflict ConflictInfo) ConflictAction) ConflictAction {
+	switch {
+	case e.overwriteAll:
+		return ActionOverwrite
+	case e.skipAll, conflictFn == nil:
+		return ActionSkip
+	}
+
+	conflict := ConflictInfo{
+		FilePath:     change.FilePath,
+		TargetRepo:   change.TargetRepo,
+		Diff:         change.Diff,
+		TemplateSize: int64(len(change.content)),
+	}
+	if info, err := os.Stat(filepath.Join(change.TargetRepo, change.FilePath)); err == nil {
+		conflict.LocalSize = info.Size()
+	}
+
+	switch action := conflictFn(conflict); action {
+	case ActionOverwriteAll:
+		e.overwriteAll = true
+		return ActionOverwrite
+	case ActionSkipAll:
+		e.skipAll = true
+		return ActionSkip
+	default:
+		return action
+	}
+}
+
+// SummarizePlan counts the changes by action.
+func SummarizePlan(changes []Change) map[PlanAction]int {
+	counts := make(map[PlanAction]int)
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	return counts
+}
+
+// ListFiles returns the paths of all files in the template, sorted.
+// The .git directory of local templates and the template's own lock are
+// left out.
+func (e *SyncEngine) ListFiles() ([]string, error) {
+	var files []string
+	if e.isLocal {
+		err := filepath.WalkDir(e.localTemplatePath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			rel, err := filepath.Rel(e.localTemplatePath, path)
+			if err != nil {
+				return err
+			}
+			if rel = filepath.ToSlash(rel); rel != LockFile {
+				files = append(files, rel)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list template files: %w", err)
+		}
+		return files, nil
+	}
+
+	if err := e.loadTree(); err != nil {
+		return nil, err
+	}
+	for filePath := range e.blobSHAs {
+		if filePath != LockFile {
+			files = append(files, filePath)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readTemplateFile reads a file from the template.
+func (e *SyncEngine) readTemplateFile(filePath string) (templateFile, error) {
+	if e.isLocal {
+		sourcePath := filepath.Join(e.localTemplatePath, filePath)
+		info, err := os.Stat(sourcePath)
+		if err != nil {
+			return templateFile{}, fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
+		}
+		content, err := os.ReadFile(sourcePath)
+		if err != nil {
+			return templateFile{}, fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
+		}
+		return templateFile{content: content, mode: info.Mode().Perm()}, nil
+	}
+
+	content, err := e.githubClient.GetFileContent(e.templateOwner, e.templateRepo, filePath, e.templateBranch)
+	if err != nil {
+		return templateFile{}, fmt.Errorf("failed to fetch file from GitHub: %w", err)
+	}
+	return templateFile{content: content, mode: 0o644}, nil
+}
+
+// writeFile writes content to destPath, creating parent directories.
+func writeFile(destPath string, content []byte, mode fs.FileMode) error {
+	parentDir := filepath.Dir(destPath)
+	if err := os.MkdirAll(parentDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", parentDir, err)
+	}
+	if err := os.WriteFile(destPath, content, mode); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// unifiedDiff returns a unified diff turning old into new. exists is false
+// when the file is being created, which is shown as a diff from /dev/null.
+func unifiedDiff(filePath string, old, new []byte, exists bool) string {
+	fromFile := "a/" + filePath
+	if !exists {
+		fromFile = "/dev/null"
+	}
+	if isBinary(old) || isBinary(new) {
+		return fmt.Sprintf("Binary files %s and b/%s differ\n", fromFile, filePath)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(old),
+		B:        splitLines(new),
+		FromFile: fromFile,
+		ToFile:   "b/" + filePath,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// splitLines splits content into lines that keep their line endings, as
+// difflib expects. A missing final newline is marked the way git does.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if last := lines[len(lines)-1]; last == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] = last + "\n\\ No newline at end of file\n"
+	}
+	return lines
+}
+
+// isBinary reports whether content looks binary, using git's heuristic of
+// a NUL byte in the first 8000 bytes.
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
@@ -0,0 +1,142 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles creates files with the given contents below root.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	root := t.TempDir()
+	tmpl := filepath.Join(root, "template")
+	target := filepath.Join(root, "target")
+	writeFiles(t, tmpl, map[string]string{
+		".github/ci.yml": "on: push\n",
+		"Makefile":       "build:\n\tgo build ./...\n",
+		"LICENSE":        "MIT\n",
+		"docs":           "not a directory in the template\n",
+	})
+	writeFiles(t, target, map[string]string{
+		"Makefile":    "build:\n\tgo build .\n",
+		"LICENSE":     "MIT\n",
+		"docs/README": "docs\n",
+	})
+
+	engine := NewLocalSyncEngine(tmpl)
+	files, err := engine.ListFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/ci.yml", "LICENSE", "Makefile", "docs"}, files)
+
+	changes, err := engine.Plan(files, []string{target}, nil)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+
+	actions := make(map[string]PlanAction)
+	for _, change := range changes {
+		actions[change.FilePath] = change.Action
+	}
+	assert.Equal(t, map[string]PlanAction{
+		".github/ci.yml": PlanCreate,
+		"LICENSE":        PlanIdentical,
+		"Makefile":       PlanUpdate,
+		"docs":           PlanSkip,
+	}, actions)
+
+	assert.Equal(t, "--- /dev/null\n+++ b/.github/ci.yml\n@@ -0,0 +1 @@\n+on: push\n", changes[0].Diff)
+	assert.Equal(t, "--- a/Makefile\n+++ b/Makefile\n@@ -1,2 +1,2 @@\n build:\n-\tgo build .\n+\tgo build ./...\n", changes[2].Diff)
+
+	// Updates are skipped unless the conflict callback allows them
+	results := engine.ApplyChanges(changes, nil, func(conflict ConflictInfo) ConflictAction {
+		assert.Equal(t, "Makefile", conflict.FilePath)
+		assert.Equal(t, changes[2].Diff, conflict.Diff)
+		assert.Equal(t, []int64{23, 19}, []int64{conflict.TemplateSize, conflict.LocalSize})
+		return ActionSkip
+	})
+	synced, unchanged, skipped, errors := GetSyncSummary(results)
+	assert.Equal(t, []int{1, 1, 2, 0}, []int{synced, unchanged, skipped, errors})
+
+	data, err := os.ReadFile(filepath.Join(target, ".github", "ci.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "on: push\n", string(data))
+	data, err = os.ReadFile(filepath.Join(target, "Makefile"))
+	require.NoError(t, err)
+	assert.Equal(t, "build:\n\tgo build .\n", string(data), "skipped update must not be written")
+
+	engine.SetOverwriteAll(true)
+	engine.ApplyChanges(changes[2:3], nil, nil)
+	data, err = os.ReadFile(filepath.Join(target, "Makefile"))
+	require.NoError(t, err)
+	assert.Equal(t, "build:\n\tgo build ./...\n", string(data))
+}
+
+func TestUnifiedDiffEdgeCases(t *testing.T) {
+	assert.Equal(t, "Binary files a/logo.png and b/logo.png differ\n",
+		unifiedDiff("logo.png", []byte("\x89PNG\x00"), []byte("\x89PNG\x00\x01"), true))
+
+	diff := unifiedDiff("VERSION", []byte("1.0"), []byte("1.1\n"), true)
+	assert.Equal(t, "--- a/VERSION\n+++ b/VERSION\n@@ -1 +1 @@\n-1.0\n\\ No newline at end of file\n+1.1\n", diff)
+}
+
+func TestChecksums(t *testing.T) {
+	// Object IDs as reported by git hash-object
+	assert.Equal(t, "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391", gitBlobSHA(nil))
+	assert.Equal(t, "ce013625030ba8dba906f756967f9e9ca394464a", gitBlobSHA([]byte("hello\n")))
+
+	engine := NewSyncEngine(nil, "owner", "template", "main")
+	engine.blobSHAs = map[string]string{"README.md": gitBlobSHA([]byte("hello\n"))}
+	target := t.TempDir()
+	writeFiles(t, target, map[string]string{"README.md": "hello\n"})
+
+	changes, err := engine.Plan([]string{"README.md"}, []string{target}, nil)
+	require.NoError(t, err, "identical files must not be downloaded")
+	assert.Equal(t, PlanIdentical, changes[0].Action)
+}
+
+func TestApplyChangesConflicts(t *testing.T) {
+	root := t.TempDir()
+	tmpl := filepath.Join(root, "template")
+	target := filepath.Join(root, "target")
+	writeFiles(t, tmpl, map[string]string{"LICENSE": "MIT\n", "Makefile": "build:\n"})
+	writeFiles(t, target, map[string]string{"LICENSE": "MIT\n", "Makefile": "all:\n"})
+
+	engine := NewLocalSyncEngine(tmpl)
+	changes, err := engine.Plan([]string{"LICENSE", "Makefile"}, []string{target}, nil)
+	require.NoError(t, err)
+
+	var conflicts []string
+	results := engine.ApplyChanges(changes, nil, func(conflict ConflictInfo) ConflictAction {
+		conflicts = append(conflicts, conflict.FilePath)
+		return ActionSkip
+	})
+
+	assert.Equal(t, []string{"Makefile"}, conflicts, "identical files are not conflicts")
+	synced, unchanged, skipped, errors := GetSyncSummary(results)
+	assert.Equal(t, []int{0, 1, 1, 0}, []int{synced, unchanged, skipped, errors})
+}
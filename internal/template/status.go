@@ -0,0 +1,181 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DriftState classifies a synced file by what changed since its last sync.
+type DriftState int
+
+const (
+	// DriftUpToDate means neither the template nor the target changed.
+	DriftUpToDate DriftState = iota
+	// DriftUpstream means the template changed and the target didn't.
+	DriftUpstream
+	// DriftLocal means the target changed and the template didn't.
+	DriftLocal
+	// DriftBoth means both the template and the target changed.
+	DriftBoth
+)
+
+// String returns the name of the state.
+func (s DriftState) String() string {
+	switch s {
+	case DriftUpToDate:
+		return "up to date"
+	case DriftUpstream:
+		return "template changed"
+	case DriftLocal:
+		return "locally modified"
+	case DriftBoth:
+		return "both changed"
+	default:
+		return "unknown"
+	}
+}
+
+// FileStatus is the drift of one synced file in a target.
+type FileStatus struct {
+	Path       string
+	TargetRepo string
+	Locked     LockedFile
+	State      DriftState
+	Detail     string // Set when the file was deleted on either side
+	Err        error
+}
+
+// EngineFunc returns the sync engine of a template source at a ref, as
+// recorded in a lock.
+type EngineFunc func(source, ref string) (*SyncEngine, error)
+
+// CheckDrift compares every file recorded in the targets' template locks
+// with the file in the target and in the current template. Engines are
+// created once per template source and ref. Targets without a lock have no
+// statuses; a lock that can't be read is reported as an error status for
+// the lock file.
+func CheckDrift(targets []string, engineFn EngineFunc) []FileStatus {
+	type engineKey struct{ source, ref string }
+	type engineResult struct {
+		engine *SyncEngine
+		err    error
+	}
+	engines := make(map[engineKey]engineResult)
+
+	var statuses []FileStatus
+	for _, targetRepo := range targets {
+		lock, err := LoadLock(targetRepo)
+		if err != nil {
+			statuses = append(statuses, FileStatus{Path: LockFile, TargetRepo: targetRepo, Err: err})
+			continue
+		}
+
+		for _, locked := range lock.Files {
+			status := FileStatus{Path: locked.Path, TargetRepo: targetRepo, Locked: locked}
+
+			key := engineKey{locked.Source, locked.Ref}
+			result, ok := engines[key]
+			if !ok {
+				result.engine, result.err = engineFn(locked.Source, locked.Ref)
+				engines[key] = result
+			}
+			if result.err != nil {
+				status.Err = result.err
+			} else {
+				status.State, status.Detail, status.Err = result.engine.drift(targetRepo, locked)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// drift classifies a locked file by comparing its recorded blob with the
+// template's and the target's current content.
+func (e *SyncEngine) drift(targetRepo string, locked LockedFile) (DriftState, string, error) {
+	upstream, err := e.templateBlob(locked.Path)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var current string
+	destPath := filepath.Join(targetRepo, locked.Path)
+	content, err := os.ReadFile(destPath)
+	switch {
+	case err == nil:
+		current = gitBlobSHA(content)
+	case !os.IsNotExist(err):
+		return 0, "", fmt.Errorf("failed to read file %s: %w", destPath, err)
+	}
+
+	var detail string
+	switch {
+	case upstream == "" && current == "":
+		detail = "removed from the template and deleted locally"
+	case upstream == "":
+		detail = "removed from the template"
+	case current == "":
+		detail = "deleted locally"
+	}
+
+	changedUpstream := upstream != locked.Blob
+	changedLocally := current != locked.Blob
+	switch {
+	case changedUpstream && changedLocally:
+		return DriftBoth, detail, nil
+	case changedUpstream:
+		return DriftUpstream, detail, nil
+	case changedLocally:
+		return DriftLocal, detail, nil
+	default:
+		return DriftUpToDate, detail, nil
+	}
+}
+
+// templateBlob returns the git blob SHA of a template file, or an empty
+// string if the template no longer has it.
+func (e *SyncEngine) templateBlob(filePath string) (string, error) {
+	if e.isLocal {
+		content, err := os.ReadFile(filepath.Join(e.localTemplatePath, filePath))
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read source file %s: %w", filePath, err)
+		}
+		return gitBlobSHA(content), nil
+	}
+
+	if e.blobSHAs == nil {
+		if err := e.loadTree(); err != nil {
+			return "", err
+		}
+	}
+	return e.blobSHAs[filePath], nil
+}
+
+// SummarizeDrift counts the statuses by state, leaving out errors.
+func SummarizeDrift(statuses []FileStatus) map[DriftState]int {
+	counts := make(map[DriftState]int)
+	for _, status := range statuses {
+		if status.Err == nil {
+			counts[status.State]++
+		}
+	}
+	return counts
+}
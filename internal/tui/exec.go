@@ -0,0 +1,450 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
+// execListRows is the most repositories shown at once in the results list.
+const execListRows = 8
+
+// ExecModel asks for a command, runs it in the selected repositories and
+// shows each repository's output.
+type ExecModel struct {
+	// Complex types first
+	input    textinput.Model
+	output   viewport.Model
+	spinner  spinner.Model
+	startAt  time.Time
+	resultCh chan tea.Msg
+
+	// Error (16 bytes)
+	err error
+
+	// Slices (24 bytes)
+	repos    []local.Repository
+	results  []local.ExecResult
+	finished []bool
+
+	// Strings (16 bytes)
+	command string
+
+	// Ints (8 bytes each)
+	jobs     int
+	done     int
+	failed   int
+	selected int
+	width    int
+	height   int
+
+	// Bools (1 byte each)
+	running bool
+	visible bool
+}
+
+// NewExecModel creates a new exec dialog.
+func NewExecModel() *ExecModel {
+	input := textinput.New()
+	input.Placeholder = "git status --short"
+	input.CharLimit = 500
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = spinnerStyle
+
+	return &ExecModel{
+		input:   input,
+		output:  viewport.New(0, 0),
+		spinner: s,
+	}
+}
+
+// Show opens the command prompt for the repositories at paths.
+func (m *ExecModel) Show(paths []string, jobs int) {
+	m.repos = make([]local.Repository, len(paths))
+	for i, path := range paths {
+		m.repos[i] = local.Repository{Name: filepath.Base(path), Path: path}
+	}
+	m.jobs = jobs
+	m.results = nil
+	m.finished = nil
+	m.err = nil
+	m.running = false
+	m.visible = true
+
+	// Keep the last command to make re-running it quick
+	m.input.SetValue(m.command)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+// Hide hides the dialog.
+func (m *ExecModel) Hide() {
+	m.visible = false
+	m.input.Blur()
+}
+
+// IsVisible returns whether the dialog is currently visible.
+func (m *ExecModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the terminal size the dialog is laid out for.
+func (m *ExecModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.layoutOutput()
+}
+
+// prompting reports whether the dialog is still asking for a command.
+func (m *ExecModel) prompting() bool {
+	return m.results == nil
+}
+
+// start runs the command in every repository using at most m.jobs workers.
+func (m *ExecModel) start() tea.Cmd {
+	m.input.Blur()
+	m.results = make([]local.ExecResult, len(m.repos))
+	m.finished = make([]bool, len(m.repos))
+	m.done = 0
+	m.failed = 0
+	m.selected = 0
+	m.running = true
+	m.startAt = time.Now()
+	m.resultCh = make(chan tea.Msg, len(m.repos))
+	m.layoutOutput()
+	m.refreshOutput()
+
+	repos, jobs, argv, ch := m.repos, m.jobs, local.ShellCommand(m.command), m.resultCh
+	run := func() tea.Msg {
+		scanner := local.NewScanner()
+		workerpool.Run(jobs, len(repos), func(i int) {
+			ch <- ExecResultMsg{Index: i, Result: scanner.ExecRepo(repos[i], argv)}
+		})
+		return nil
+	}
+
+	return tea.Batch(m.spinner.Tick, run, m.waitForResult())
+}
+
+// waitForResult waits for the next repository to finish.
+func (m *ExecModel) waitForResult() tea.Cmd {
+	ch := m.resultCh
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// Update handles input and results for the dialog.
+func (m *ExecModel) Update(msg tea.Msg) (*ExecModel, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case ExecResultMsg:
+		if msg.Index >= len(m.results) {
+			return m, nil
+		}
+		m.results[msg.Index] = msg.Result
+		m.finished[msg.Index] = true
+		m.done++
+		if !msg.Result.Passed() {
+			m.failed++
+		}
+		if msg.Index == m.selected {
+			m.refreshOutput()
+		}
+		if m.done == len(m.repos) {
+			m.running = false
+			return m, nil
+		}
+		return m, m.waitForResult()
+
+	case spinner.TickMsg:
+		if !m.running {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		if m.prompting() {
+			return m.updatePrompt(msg)
+		}
+		return m.updateResults(msg)
+	}
+
+	return m, nil
+}
+
+// updatePrompt handles keys while the command is being entered.
+func (m *ExecModel) updatePrompt(msg tea.KeyMsg) (*ExecModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.Hide()
+		return m, nil
+
+	case "enter":
+		command := strings.TrimSpace(m.input.Value())
+		if command == "" {
+			m.err = fmt.Errorf("enter a command to run")
+			return m, nil
+		}
+		m.command = command
+		return m, m.start()
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.err = nil
+	return m, cmd
+}
+
+// updateResults handles keys while the results are shown.
+func (m *ExecModel) updateResults(msg tea.KeyMsg) (*ExecModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// The commands can't be interrupted, so wait for them to finish
+		if !m.running {
+			m.Hide()
+		}
+		return m, nil
+
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+			m.refreshOutput()
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.selected < len(m.repos)-1 {
+			m.selected++
+			m.refreshOutput()
+		}
+		return m, nil
+
+	case "r":
+		if !m.running {
+			return m, m.start()
+		}
+		return m, nil
+	}
+
+	// pgup/pgdown and friends scroll the output
+	var cmd tea.Cmd
+	m.output, cmd = m.output.Update(msg)
+	return m, cmd
+}
+
+// dialogWidth returns the width of the dialog's content.
+func (m *ExecModel) dialogWidth() int {
+	width := m.width - 12
+	if m.width == 0 {
+		width = 88
+	}
+	return min(max(width, 40), 120)
+}
+
+// listRows returns how many repositories the results list shows.
+func (m *ExecModel) listRows() int {
+	return min(len(m.repos), execListRows)
+}
+
+// layoutOutput sizes the output viewport to fill the rest of the dialog.
+func (m *ExecModel) layoutOutput() {
+	height := m.height
+	if height == 0 {
+		height = 30
+	}
+	// Border, padding, title, summary, list, output header and footer
+	m.output.Width = m.dialogWidth()
+	m.output.Height = max(height-m.listRows()-18, 3)
+	m.refreshOutput()
+}
+
+// refreshOutput shows the selected repository's output in the viewport.
+func (m *ExecModel) refreshOutput() {
+	if m.selected >= len(m.results) {
+		m.output.SetContent("")
+		return
+	}
+	if !m.finished[m.selected] {
+		m.output.SetContent(helpDescStyle.Render("Running…"))
+		m.output.GotoTop()
+		return
+	}
+
+	result := m.results[m.selected]
+	var b strings.Builder
+	b.WriteString(result.Stdout)
+	if result.Stderr != "" {
+		if result.Stdout != "" && !strings.HasSuffix(result.Stdout, "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString(warningStyle.Render("stderr:"))
+		b.WriteString("\n")
+		b.WriteString(result.Stderr)
+	}
+	if result.ExitCode < 0 {
+		b.WriteString(errorStyle.Render(result.Err.Error()))
+	}
+	if b.Len() == 0 {
+		b.WriteString(helpDescStyle.Render("(no output)"))
+	}
+
+	wrapped := lipgloss.NewStyle().Width(m.output.Width).Render(strings.TrimRight(b.String(), "\n"))
+	m.output.SetContent(wrapped)
+	m.output.GotoTop()
+}
+
+// View renders the dialog.
+func (m *ExecModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	if m.prompting() {
+		return m.viewPrompt()
+	}
+	return m.viewResults()
+}
+
+// viewPrompt renders the command prompt.
+func (m *ExecModel) viewPrompt() string {
+	var b strings.Builder
+
+	noun := "repositories"
+	if len(m.repos) == 1 {
+		noun = "repository"
+	}
+	b.WriteString(dialogTitleStyle.Render(fmt.Sprintf("Run in %d %s", len(m.repos), noun)))
+	b.WriteString("\n\n")
+	b.WriteString(helpDescStyle.Render("Command to run in each repository (through sh -c)"))
+	b.WriteString("\n")
+	b.WriteString(focusedInputStyle.Render(m.input.View()))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(
+		"enter", "run",
+		"esc", "cancel",
+	))
+
+	return dialogStyle.Render(b.String())
+}
+
+// viewResults renders the per-repository results and the selected output.
+func (m *ExecModel) viewResults() string {
+	width := m.dialogWidth()
+	var b strings.Builder
+
+	b.WriteString(dialogTitleStyle.Render("$ " + m.command))
+	b.WriteString("\n\n")
+
+	passed := m.done - m.failed
+	summary := fmt.Sprintf("%d passed, %d failed", passed, m.failed)
+	if m.running {
+		summary = fmt.Sprintf("%s %d/%d done · %s", m.spinner.View(), m.done, len(m.repos), summary)
+	} else {
+		summary = fmt.Sprintf("%s in %s", summary, time.Since(m.startAt).Round(time.Second))
+	}
+	if m.failed > 0 {
+		b.WriteString(errorStyle.Render(summary))
+	} else {
+		b.WriteString(successStyle.Render(summary))
+	}
+	b.WriteString("\n\n")
+
+	// Keep the selected repository inside the visible window
+	rows := m.listRows()
+	first := min(max(m.selected-rows/2, 0), len(m.repos)-rows)
+	for i := first; i < first+rows; i++ {
+		b.WriteString(m.renderResultRow(i))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	header := "Output: " + m.repos[m.selected].Name
+	if m.output.TotalLineCount() > m.output.Height {
+		header += fmt.Sprintf(" (%d%%)", int(m.output.ScrollPercent()*100))
+	}
+	b.WriteString(sectionHeaderStyle.Render(header))
+	b.WriteString("\n")
+	b.WriteString(m.output.View())
+	b.WriteString("\n\n")
+
+	if m.running {
+		b.WriteString(RenderFooter(
+			"↑/↓", "repository",
+			"pgup/pgdown", "scroll",
+		))
+	} else {
+		b.WriteString(RenderFooter(
+			"↑/↓", "repository",
+			"pgup/pgdown", "scroll",
+			"r", "run again",
+			"esc", "close",
+		))
+	}
+
+	return dialogStyle.Width(width + 4).Render(b.String())
+}
+
+// renderResultRow renders one repository in the results list.
+func (m *ExecModel) renderResultRow(i int) string {
+	repo := m.repos[i]
+
+	var status string
+	switch {
+	case !m.finished[i]:
+		status = helpDescStyle.Render("… running")
+	case m.results[i].Passed():
+		status = successStyle.Render("✓") + " " + helpDescStyle.Render(m.results[i].Duration.Round(time.Millisecond).String())
+	default:
+		result := m.results[i]
+		detail := fmt.Sprintf("exit %d", result.ExitCode)
+		if result.ExitCode < 0 {
+			detail = "did not start"
+		}
+		status = errorStyle.Render("✗ " + detail)
+	}
+
+	if i == m.selected {
+		name := lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Render(repo.Name)
+		return "▸ " + name + "  " + status
+	}
+	return "  " + repo.Name + "  " + status
+}
@@ -46,7 +46,13 @@ type KeyMap struct {
 	Search key.Binding
 	Sort   key.Binding
 	Enter  key.Binding
+	Pull   key.Binding
+	Exec   key.Binding
 	Owner  key.Binding
+
+	// Personal tab filters
+	Affiliation key.Binding
+	Visibility  key.Binding
 }
 
 // Keys is the global key map for the application.
@@ -136,10 +142,28 @@ var Keys = KeyMap{
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "sync/confirm"),
 	),
+	Pull: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pull selected (fast-forward)"),
+	),
+	Exec: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "run command in selected (local)"),
+	),
 	Owner: key.NewBinding(
 		key.WithKeys("o"),
 		key.WithHelp("o", "change owner"),
 	),
+
+	// Personal tab filters
+	Affiliation: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "cycle all/owned/collaborator"),
+	),
+	Visibility: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "cycle visibility"),
+	),
 }
 
 // ShortHelp returns a list of key bindings for short help.
@@ -154,6 +178,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Select, k.SelectAll, k.SelectNone},
 		{k.Tab1, k.Tab2, k.Tab3, k.TabNext},
 		{k.Search, k.Sort, k.Owner, k.Settings},
-		{k.Enter, k.Help, k.Escape, k.Quit},
+		{k.Affiliation, k.Visibility},
+		{k.Enter, k.Pull, k.Exec, k.Help, k.Escape, k.Quit},
 	}
 }
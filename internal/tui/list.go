@@ -16,8 +16,10 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -32,9 +34,19 @@ type ListItem interface {
 	Title() string
 	Description() string
 	Metadata() map[string]string
+	SortKeys() SortKeys
 	IsArchived() bool
 }
 
+// SortKeys holds the typed values list items are ordered by. Metadata is
+// formatted for display and must not be used for sorting.
+type SortKeys struct {
+	Name    string
+	Stars   int
+	Updated time.Time // Zero when unknown
+	Size    int64     // Bytes
+}
+
 // GitHubRepoItem wraps a GitHub repository as a ListItem.
 type GitHubRepoItem struct {
 	repo github.Repository
@@ -73,11 +85,33 @@ func (i GitHubRepoItem) Metadata() map[string]string {
 	if i.repo.IsArchived {
 		meta["archived"] = "Archived"
 	}
+	if i.repo.Fork {
+		meta["fork"] = "🍴 Fork"
+	}
+	if updated := i.repo.LastActivity(); !updated.IsZero() {
+		meta["updated"] = local.FormatAge(updated)
+	}
+	if i.repo.Size > 0 {
+		meta["size"] = local.FormatSize(int64(i.repo.Size) * 1024)
+	}
+	if len(i.repo.Topics) > 0 {
+		meta["topics"] = strings.Join(i.repo.Topics, ", ")
+	}
 	meta["clone_url"] = i.repo.CloneURL
 
 	return meta
 }
 
+// SortKeys returns the values used to order the repository.
+func (i GitHubRepoItem) SortKeys() SortKeys {
+	return SortKeys{
+		Name:    i.repo.Name,
+		Stars:   i.repo.Stars,
+		Updated: i.repo.LastActivity(),
+		Size:    int64(i.repo.Size) * 1024,
+	}
+}
+
 // IsArchived returns true if the repository is archived.
 func (i GitHubRepoItem) IsArchived() bool {
 	return i.repo.IsArchived
@@ -113,18 +147,104 @@ func (i LocalRepoItem) Metadata() map[string]string {
 	if i.repo.Branch != "" {
 		meta["branch"] = i.repo.Branch
 	}
-	if i.repo.IsGitRepo {
-		meta["type"] = "📦 Git Repository"
+	switch i.repo.Kind {
+	case local.KindWorktree:
+		meta["type"] = "🌿 Worktree of " + filepath.Base(i.repo.Parent)
+	case local.KindSubmodule:
+		meta["type"] = "🧩 Submodule of " + filepath.Base(i.repo.Parent)
+	case local.KindBare:
+		meta["type"] = "🗄 Bare Repository"
+	default:
+		if i.repo.IsGitRepo {
+			meta["type"] = "📦 Git Repository"
+		}
+	}
+	if !i.repo.LastCommit.IsZero() {
+		meta["updated"] = local.FormatAge(i.repo.LastCommit)
 	}
 
 	return meta
 }
 
+// SortKeys returns the values used to order the repository.
+func (i LocalRepoItem) SortKeys() SortKeys {
+	return SortKeys{
+		Name:    i.repo.Name,
+		Updated: i.repo.LastCommit,
+		Size:    i.repo.Size,
+	}
+}
+
 // IsArchived returns false for local repositories (they cannot be archived).
 func (i LocalRepoItem) IsArchived() bool {
 	return false
 }
 
+// ParentID returns the ID of the main worktree of a linked worktree or the
+// superproject of a submodule.
+func (i LocalRepoItem) ParentID() string {
+	return i.repo.Parent
+}
+
+// childItem is implemented by items listed under another item, such as
+// linked worktrees under their main repository.
+type childItem interface {
+	ParentID() string
+}
+
+// groupItems moves items listed under a parent directly after it, keeping
+// their relative order, and returns the nesting depth of each moved item.
+// Items whose parent isn't listed stay where they are.
+func groupItems(items []ListItem) ([]ListItem, map[string]int) {
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[item.ID()] = true
+	}
+
+	parentOf := func(item ListItem) string {
+		if child, ok := item.(childItem); ok {
+			if parent := child.ParentID(); parent != item.ID() && present[parent] {
+				return parent
+			}
+		}
+		return ""
+	}
+
+	children := make(map[string][]ListItem)
+	for _, item := range items {
+		if parent := parentOf(item); parent != "" {
+			children[parent] = append(children[parent], item)
+		}
+	}
+	if len(children) == 0 {
+		return items, nil
+	}
+
+	grouped := make([]ListItem, 0, len(items))
+	depths := make(map[string]int)
+	var add func(item ListItem, depth int)
+	add = func(item ListItem, depth int) {
+		grouped = append(grouped, item)
+		if depth > 0 {
+			depths[item.ID()] = depth
+		}
+		for _, child := range children[item.ID()] {
+			add(child, depth+1)
+		}
+	}
+	for _, item := range items {
+		if parentOf(item) == "" {
+			add(item, 0)
+		}
+	}
+
+	// Items in a parent cycle have no root to be added under
+	if len(grouped) < len(items) {
+		return items, nil
+	}
+	return grouped, depths
+}
+
 // SortMode represents different ways to sort repositories.
 type SortMode int
 
@@ -132,6 +252,9 @@ const (
 	SortByName SortMode = iota
 	SortByStars
 	SortByUpdated
+	SortBySize
+
+	sortModeCount = 4
 )
 
 // String returns the string representation of the sort mode.
@@ -143,11 +266,33 @@ func (s SortMode) String() string {
 		return "Stars"
 	case SortByUpdated:
 		return "Updated"
+	case SortBySize:
+		return "Size"
 	default:
 		return "Name"
 	}
 }
 
+// less reports whether a sorts before b. Stars, updated time and size sort
+// in descending order; ties fall back to the name.
+func (s SortMode) less(a, b SortKeys) bool {
+	switch s {
+	case SortByStars:
+		if a.Stars != b.Stars {
+			return a.Stars > b.Stars
+		}
+	case SortByUpdated:
+		if !a.Updated.Equal(b.Updated) {
+			return a.Updated.After(b.Updated)
+		}
+	case SortBySize:
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+	}
+	return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+}
+
 // ListModel manages a generic list of items.
 type ListModel struct {
 	// Complex type
@@ -160,8 +305,9 @@ type ListModel struct {
 	items    []ListItem
 	filtered []ListItem
 
-	// Map (8 bytes pointer)
+	// Maps (8 bytes pointer each)
 	checked map[string]bool
+	depths  map[string]int // Nesting depth of items grouped under a parent
 
 	// Ints (8 bytes each)
 	selected       int
@@ -174,6 +320,7 @@ type ListModel struct {
 	// Bools (1 byte each)
 	searching bool
 	loading   bool
+	scanning  bool // More items are still being found
 }
 
 // NewListModel creates a new list model.
@@ -204,6 +351,66 @@ func (m *ListModel) SetItems(items []ListItem) {
 	m.selected = 0
 }
 
+// MergeItems adds items to the list, replacing existing items with the same
+// ID. The search filter is kept and the cursor stays on the same item.
+func (m *ListModel) MergeItems(items []ListItem) {
+	index := make(map[string]int, len(m.items))
+	for i, item := range m.items {
+		index[item.ID()] = i
+	}
+
+	m.keepSelection(func() {
+		for _, item := range items {
+			if i, ok := index[item.ID()]; ok {
+				m.items[i] = item
+				continue
+			}
+			index[item.ID()] = len(m.items)
+			m.items = append(m.items, item)
+		}
+	})
+}
+
+// RetainItems removes the items keep rejects, clearing their selection.
+func (m *ListModel) RetainItems(keep func(id string) bool) {
+	m.keepSelection(func() {
+		items := m.items[:0:0]
+		for _, item := range m.items {
+			if keep(item.ID()) {
+				items = append(items, item)
+			} else {
+				delete(m.checked, item.ID())
+			}
+		}
+		m.items = items
+	})
+}
+
+// keepSelection runs update, then re-sorts and re-filters the items and
+// moves the cursor back to the item it was on, if that is still listed.
+func (m *ListModel) keepSelection(update func()) {
+	var selectedID string
+	if m.selected >= 0 && m.selected < len(m.filtered) {
+		selectedID = m.filtered[m.selected].ID()
+	}
+
+	update()
+	m.sortItems()
+	m.filterItems()
+
+	for i, item := range m.filtered {
+		if item.ID() == selectedID {
+			m.selected = i
+			break
+		}
+	}
+}
+
+// SetScanning sets whether more items are still being found.
+func (m *ListModel) SetScanning(scanning bool) {
+	m.scanning = scanning
+}
+
 // SetLoading sets the loading state.
 func (m *ListModel) SetLoading(loading bool) {
 	m.loading = loading
@@ -215,6 +422,11 @@ func (m *ListModel) SetError(err error) {
 	m.loading = false
 }
 
+// IsSearching returns whether the search input is active.
+func (m *ListModel) IsSearching() bool {
+	return m.searching
+}
+
 // GetSelectedItems returns the list of selected item IDs.
 func (m *ListModel) GetSelectedItems() []string {
 	var selected []string
@@ -324,7 +536,7 @@ func (m *ListModel) handleNavigation(msg tea.KeyMsg) (*ListModel, tea.Cmd) {
 
 	case "s":
 		// Cycle sort mode
-		m.sortMode = (m.sortMode + 1) % 3
+		m.sortMode = (m.sortMode + 1) % sortModeCount
 		m.sortItems()
 		m.filterItems()
 		m.selected = 0
@@ -352,24 +564,9 @@ func (m *ListModel) sortItems() {
 
 	// Sort function based on current mode
 	sortFn := func(items []ListItem) {
-		switch m.sortMode {
-		case SortByName:
-			sort.Slice(items, func(i, j int) bool {
-				return strings.ToLower(items[i].Title()) < strings.ToLower(items[j].Title())
-			})
-
-		case SortByStars:
-			sort.Slice(items, func(i, j int) bool {
-				starsI := items[i].Metadata()["stars"]
-				starsJ := items[j].Metadata()["stars"]
-				return starsJ < starsI
-			})
-
-		case SortByUpdated:
-			sort.Slice(items, func(i, j int) bool {
-				return strings.ToLower(items[i].Title()) < strings.ToLower(items[j].Title())
-			})
-		}
+		sort.SliceStable(items, func(i, j int) bool {
+			return m.sortMode.less(items[i].SortKeys(), items[j].SortKeys())
+		})
 	}
 
 	// Sort each group independently
@@ -377,6 +574,7 @@ func (m *ListModel) sortItems() {
 	sortFn(archived)
 
 	// Recombine: active first, then archived
+	active, m.depths = groupItems(active)
 	m.items = append(active, archived...)
 }
 
@@ -493,6 +691,9 @@ func (m *ListModel) View(width, height int) string {
 
 		// Main line - just the title, no description
 		title := item.Title()
+		if depth := m.depths[item.ID()]; depth > 0 {
+			title = strings.Repeat("  ", depth-1) + "↳ " + title
+		}
 
 		// Use appropriate renderer based on archived status
 		if isArchived {
@@ -516,7 +717,7 @@ func (m *ListModel) View(width, height int) string {
 			meta := item.Metadata()
 			if len(meta) > 0 {
 				var metaParts []string
-				// Order: language, stars, visibility, archived, branch, size, type, path
+				// Order: language, stars, visibility, archived, fork, updated, branch, size, type, topics, path
 				if v, ok := meta["language"]; ok {
 					metaParts = append(metaParts, v)
 				}
@@ -529,6 +730,12 @@ func (m *ListModel) View(width, height int) string {
 				if v, ok := meta["archived"]; ok {
 					metaParts = append(metaParts, v)
 				}
+				if v, ok := meta["fork"]; ok {
+					metaParts = append(metaParts, v)
+				}
+				if v, ok := meta["updated"]; ok {
+					metaParts = append(metaParts, fmt.Sprintf("Updated %s", v))
+				}
 				if v, ok := meta["branch"]; ok {
 					metaParts = append(metaParts, fmt.Sprintf("Branch: %s", v))
 				}
@@ -538,6 +745,9 @@ func (m *ListModel) View(width, height int) string {
 				if v, ok := meta["type"]; ok {
 					metaParts = append(metaParts, v)
 				}
+				if v, ok := meta["topics"]; ok {
+					metaParts = append(metaParts, truncate(v, 40))
+				}
 				if v, ok := meta["path"]; ok {
 					metaParts = append(metaParts, truncate(v, 60))
 				}
@@ -557,6 +767,9 @@ func (m *ListModel) View(width, height int) string {
 		if m.selected >= 0 && m.selected < len(m.filtered) {
 			navHint += fmt.Sprintf(" (selected: %d)", m.selected+1)
 		}
+		if m.scanning {
+			navHint += " • scanning…"
+		}
 		lines = append(lines, RenderMetadata(navHint))
 	}
 
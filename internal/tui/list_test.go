@@ -0,0 +1,123 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/local"
+)
+
+// TestListSorting tests that each sort mode orders by its typed key.
+func TestListSorting(t *testing.T) {
+	now := time.Now()
+	repos := []github.Repository{
+		{Name: "alpha", FullName: "o/alpha", Stars: 9, Size: 20, PushedAt: now.Add(-48 * time.Hour)},
+		{Name: "beta", FullName: "o/beta", Stars: 10, Size: 300, PushedAt: now.Add(-time.Hour)},
+		{Name: "gamma", FullName: "o/gamma", Stars: 100, Size: 100, UpdatedAt: now.Add(-24 * time.Hour)},
+		{Name: "delta", FullName: "o/delta", Stars: 1000, PushedAt: now, IsArchived: true},
+	}
+
+	tests := []struct {
+		mode     SortMode
+		expected []string
+	}{
+		{mode: SortByName, expected: []string{"alpha", "beta", "gamma", "delta"}},
+		{mode: SortByStars, expected: []string{"gamma", "beta", "alpha", "delta"}},
+		{mode: SortByUpdated, expected: []string{"beta", "gamma", "alpha", "delta"}},
+		{mode: SortBySize, expected: []string{"beta", "gamma", "alpha", "delta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			list := NewListModel()
+			list.sortMode = tt.mode
+			list.SetItems(FromGitHubRepos(repos))
+
+			if len(list.items) != len(tt.expected) {
+				t.Fatalf("Expected %d items, got %d", len(tt.expected), len(list.items))
+			}
+			for i, name := range tt.expected {
+				if got := list.items[i].Title(); got != name {
+					t.Errorf("Position %d: expected %q, got %q", i, name, got)
+				}
+			}
+		})
+	}
+}
+
+// TestListMergeAndRetain tests that merged items replace items with the same
+// ID and that the cursor follows the selected item.
+func TestListMergeAndRetain(t *testing.T) {
+	list := NewListModel()
+	list.SetItems(FromLocalRepos([]local.Repository{
+		{Name: "bravo", Path: "/src/bravo", Branch: "main"},
+		{Name: "delta", Path: "/src/delta"},
+	}))
+	list.selected = 1 // delta
+	list.checked["/src/bravo"] = true
+
+	list.MergeItems(FromLocalRepos([]local.Repository{
+		{Name: "alpha", Path: "/src/alpha"},
+		{Name: "bravo", Path: "/src/bravo", Branch: "dev"},
+	}))
+
+	if len(list.items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(list.items))
+	}
+	if got := list.items[1].(LocalRepoItem).repo.Branch; got != "dev" {
+		t.Errorf("Expected merged item to replace the old one, got branch %q", got)
+	}
+	if got := list.filtered[list.selected].Title(); got != "delta" {
+		t.Errorf("Expected cursor to stay on delta, got %q", got)
+	}
+
+	list.RetainItems(func(id string) bool { return id != "/src/bravo" })
+
+	if len(list.items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(list.items))
+	}
+	if list.GetSelectedCount() != 0 {
+		t.Errorf("Expected removed item to be deselected")
+	}
+	if got := list.filtered[list.selected].Title(); got != "delta" {
+		t.Errorf("Expected cursor to stay on delta, got %q", got)
+	}
+}
+
+// TestListGroupsWorktrees tests that worktrees and submodules are listed
+// under the repository they belong to.
+func TestListGroupsWorktrees(t *testing.T) {
+	list := NewListModel()
+	list.SetItems(FromLocalRepos([]local.Repository{
+		{Name: "zeta", Path: "/src/zeta"},
+		{Name: "app-feature", Path: "/src/app-feature", Kind: local.KindWorktree, Parent: "/src/app"},
+		{Name: "app", Path: "/src/app"},
+		{Name: "app/libs/ui", Path: "/src/app/libs/ui", Kind: local.KindSubmodule, Parent: "/src/app"},
+		{Name: "orphan", Path: "/src/orphan", Kind: local.KindWorktree, Parent: "/elsewhere/main"},
+	}))
+
+	expected := []string{"app", "app-feature", "app/libs/ui", "orphan", "zeta"}
+	for i, name := range expected {
+		if got := list.items[i].Title(); got != name {
+			t.Errorf("Position %d: expected %q, got %q", i, name, got)
+		}
+	}
+	if list.depths["/src/app-feature"] != 1 || list.depths["/src/orphan"] != 0 {
+		t.Errorf("Unexpected nesting depths: %v", list.depths)
+	}
+}
@@ -14,6 +14,13 @@
 
 package tui
 
+import (
+	"github.com/MoshPitCodes/reposync/internal/clone"
+	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/template"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
+)
+
 // Mode messages
 
 // SwitchModeMsg is sent to switch between different view modes.
@@ -39,6 +46,18 @@ type OrgsLoadedMsg struct {
 	Orgs []string
 }
 
+// LocalReposFoundMsg delivers repositories found by a local scan as they
+// are found. The first batch of a scan has Reset set; Done is set on the
+// last one. A first batch with Cached set holds the repositories from the
+// scan cache, which the following batches update.
+type LocalReposFoundMsg struct {
+	Scan   <-chan local.Repository
+	Repos  []local.Repository
+	Reset  bool
+	Cached bool
+	Done   bool
+}
+
 // LoadErrorMsg is sent when an error occurs during data loading.
 type LoadErrorMsg struct {
 	Err error
@@ -56,18 +75,23 @@ type SettingsCloseMsg struct {
 
 // Sync messages
 
+// SyncConfirmMsg is sent when the sync confirmation dialog is closed.
+type SyncConfirmMsg struct {
+	Options   clone.Options
+	Confirmed bool
+}
+
 // StartSyncMsg is sent to start the sync process.
 type StartSyncMsg struct {
 	Repos []string
 }
 
-// SyncProgressMsg is sent to update sync progress.
+// SyncProgressMsg is sent by sync workers when a repository starts, finishes or fails.
 type SyncProgressMsg struct {
-	Current int
-	Total   int
-	Repo    string
-	Success bool
-	Err     error
+	Repo   string
+	Status workerpool.Status
+	Err    error
+	Detail string // What a successful copy carried over
 }
 
 // SyncCompleteMsg is sent when sync is complete.
@@ -79,6 +103,8 @@ type SyncCompleteMsg struct {
 type SyncResult struct {
 	Repo    string
 	Success bool
+	Skipped string // Why a pull left the repository unchanged
+	Detail  string // What a mirror copy carried over
 	Error   error
 }
 
@@ -158,6 +184,7 @@ type TemplateTargetsSelectedMsg struct {
 type TemplateConflictMsg struct {
 	FilePath       string
 	TargetRepoPath string
+	Diff           string // Unified diff from the local file to the template
 	TemplateSize   int64
 	LocalSize      int64
 }
@@ -186,14 +213,38 @@ type TemplateSyncProgressMsg struct {
 	TargetRepo  string
 }
 
+// TemplatePlanLoadedMsg is sent when the changes a template sync would make
+// have been computed.
+type TemplatePlanLoadedMsg struct {
+	Changes []template.Change
+	Err     error
+}
+
+// TemplateDriftLoadedMsg is sent when the template drift of the local
+// repositories has been checked.
+type TemplateDriftLoadedMsg struct {
+	Statuses []template.FileStatus
+}
+
 // TemplateSyncCompleteMsg is sent when template sync finishes.
 type TemplateSyncCompleteMsg struct {
-	Synced  int
-	Skipped int
-	Errors  int
+	Synced     int
+	Unchanged  int
+	Skipped    int
+	Errors     int
+	Merged     int // Synced files that were merged
+	Conflicted int // Merged files with conflict markers
 }
 
 // TemplateStepChangeMsg is sent when the template workflow step changes.
 type TemplateStepChangeMsg struct {
 	Step int
 }
+
+// Exec messages
+
+// ExecResultMsg is sent when a command finishes in one repository.
+type ExecResultMsg struct {
+	Result local.ExecResult
+	Index  int
+}
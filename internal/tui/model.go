@@ -16,11 +16,16 @@ package tui
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/MoshPitCodes/reposync/internal/clone"
 	"github.com/MoshPitCodes/reposync/internal/config"
 	"github.com/MoshPitCodes/reposync/internal/github"
 	"github.com/MoshPitCodes/reposync/internal/local"
@@ -38,7 +43,10 @@ type Model struct {
 	progress         *InlineProgressModel
 	ownerSelector    *OwnerSelectorModel
 	repoExistsDialog *RepoExistsDialogModel
+	syncConfirm      *SyncConfirmModel
+	exec             *ExecModel
 	githubClient     *github.Client
+	scanCache        *local.Cache // Nil when the cache can't be opened
 
 	// Template mode components
 	templateState    *TemplateSyncState
@@ -46,15 +54,22 @@ type Model struct {
 	templateTree     *TemplateTreeModel
 	templateTargets  *TemplateTargetsModel
 	templateConflict *TemplateConflictModel
+	templateReview   *TemplateReviewModel
+	templateDrift    *TemplateDriftModel
 	templateEngine   *template.SyncEngine
 
+	// Maps (8 bytes)
+	githubClients map[string]*github.Client // Clients keyed by host
+	localSeen     map[string]bool           // Paths the current local scan found
+
 	// Slices (24 bytes)
 	orgs            []string
 	localRepoPaths  []string // Cached local repo paths for template targets
 
 	// Strings (16 bytes each)
-	owner    string
-	username string
+	owner      string
+	username   string
+	visibility string // Personal tab visibility filter
 
 	// Ints (8 bytes each)
 	width          int
@@ -65,8 +80,9 @@ type Model struct {
 	footerHeight   int
 	listHeight     int
 
-	// Enum (platform-dependent, typically 4-8 bytes)
-	mode ViewMode
+	// Enums (platform-dependent, typically 4-8 bytes)
+	mode         ViewMode
+	personalView PersonalRepoView
 
 	// Bools (1 byte each, grouped together)
 	showSettings     bool
@@ -77,6 +93,12 @@ type Model struct {
 
 	// Channel for template sync progress updates
 	templateSyncProgressChan chan tea.Msg
+
+	// Channel the conflict dialog answers the blocked template sync on
+	templateConflictResponses chan template.ConflictAction
+
+	// Local scan currently populating the list
+	localScan <-chan local.Repository
 }
 
 // NewModel creates a new unified model starting in Personal mode.
@@ -98,6 +120,16 @@ func NewModel(cfg *config.Config) (Model, error) {
 	if err != nil {
 		return Model{}, err
 	}
+	protocol, err := github.ParseProtocol(mergedCfg.Protocol)
+	if err != nil {
+		return Model{}, err
+	}
+	client.SetProtocol(protocol)
+	repoLayout, err := mergedCfg.GetLayout()
+	if err != nil {
+		return Model{}, err
+	}
+	client.SetLayout(repoLayout)
 
 	username, err := client.GetCurrentUser()
 	if err != nil {
@@ -119,32 +151,44 @@ func NewModel(cfg *config.Config) (Model, error) {
 		recentTemplates = persistedCfg.RecentTemplates
 	}
 
+	// The scan cache only speeds up the Local tab, so scan without it when
+	// it can't be opened
+	scanCache, _ := local.OpenCache()
+
 	return Model{
 		config:           mergedCfg,
 		store:            store,
 		mode:             ModePersonal,
 		owner:            owner,
 		username:         username,
+		visibility:       github.VisibilityAll,
+		personalView:     PersonalViewAll,
 		orgs:             []string{},
 		localRepoPaths:   []string{},
 		tabs:             NewTabBarModel(),
 		list:             list,
 		settings:         NewSettingsModel(store),
-		progress:         NewInlineProgressModel(),
-		ownerSelector:    NewOwnerSelectorModel(username),
+		progress:         NewInlineProgressModel(client),
+		ownerSelector:    NewOwnerSelectorModel(username, mergedCfg.OwnerHosts),
 		repoExistsDialog: NewRepoExistsDialogModel(),
+		syncConfirm:      NewSyncConfirmModel(),
+		exec:             NewExecModel(),
 		templateState:    NewTemplateSyncState(),
 		templateSelector: NewTemplateSelectorModel(recentTemplates),
 		templateTree:     nil, // Created when tree is loaded
 		templateTargets:  NewTemplateTargetsModel(),
 		templateConflict: NewTemplateConflictModel(),
+		templateReview:   NewTemplateReviewModel(),
+		templateDrift:    NewTemplateDriftModel(),
 		templateEngine:   nil, // Created when sync starts
+		scanCache:        scanCache,
 		showSettings:     false,
 		showHelp:         false,
 		syncing:          false,
 		quitting:         false,
 		templateSyncing:  false,
 		githubClient:     client,
+		githubClients:    map[string]*github.Client{client.Host(): client},
 	}, nil
 }
 
@@ -159,6 +203,13 @@ func NewGitHubModel(cfg *config.Config, owner string) (Model, error) {
 	model.mode = ModePersonal
 	model.tabs.SetActive(ModePersonal)
 
+	// Enterprise owners are not the github.com user, so list them as an owner
+	if model.config.HostForOwner(owner) != "" {
+		model.mode = ModeOrganization
+		model.tabs.SetActive(ModeOrganization)
+		model.ownerSelector.SetSelectedOwner(owner, true)
+	}
+
 	return model, nil
 }
 
@@ -196,29 +247,42 @@ func (m *Model) loadOrgs() tea.Cmd {
 
 // loadRepositories loads repositories based on the current mode.
 func (m *Model) loadRepositories() tea.Cmd {
+	ownerClient, clientErr := m.clientForOwner(m.owner)
+
 	return func() tea.Msg {
 		switch m.mode {
 		case ModePersonal:
-			repos, err := m.githubClient.ListUserRepos(m.username)
+			repos, err := m.githubClient.ListAuthenticatedUserRepos(github.UserRepoOptions{
+				Affiliations: m.personalView.Affiliations(),
+				Visibility:   m.visibility,
+			})
 			if err != nil {
 				return LoadErrorMsg{Err: err}
 			}
 			return ReposLoadedMsg{Items: FromGitHubRepos(repos)}
 
 		case ModeOrganization:
-			repos, err := m.githubClient.ListOrgRepos(m.owner)
+			if clientErr != nil {
+				return LoadErrorMsg{Err: clientErr}
+			}
+			repos, err := ownerClient.ListOrgRepos(m.owner)
 			if err != nil {
 				return LoadErrorMsg{Err: err}
 			}
 			return ReposLoadedMsg{Items: FromGitHubRepos(repos)}
 
 		case ModeLocal:
+			// Show the cached repositories right away while the scan checks them
+			cached := m.scanCache.Repositories(m.config.SourceDirs)
+
 			scanner := local.NewScanner()
-			repos, err := scanner.ScanMultipleDirectories(m.config.SourceDirs)
-			if err != nil {
-				return LoadErrorMsg{Err: err}
+			scanner.SetScanOptions(m.config.ScanOptions())
+			scanner.SetCache(m.scanCache)
+			scan := scanner.Scan(m.config.SourceDirs)
+			if len(cached) > 0 {
+				return LocalReposFoundMsg{Scan: scan, Repos: cached, Reset: true, Cached: true}
 			}
-			return ReposLoadedMsg{Items: FromLocalRepos(repos)}
+			return readLocalScan(scan, true)
 
 		case ModeTemplate:
 			// Template mode doesn't load a repo list - it shows a workflow
@@ -229,19 +293,95 @@ func (m *Model) loadRepositories() tea.Cmd {
 	}
 }
 
+// localScanBatchDelay is how long repositories found by a local scan are
+// collected before they are added to the list.
+const localScanBatchDelay = 100 * time.Millisecond
+
+// readLocalScan waits for the next repositories of a local scan, batching
+// the ones found within localScanBatchDelay so the list isn't re-sorted for
+// every repository.
+func readLocalScan(scan <-chan local.Repository, reset bool) tea.Msg {
+	msg := LocalReposFoundMsg{Scan: scan, Reset: reset}
+
+	timer := time.NewTimer(localScanBatchDelay)
+	defer timer.Stop()
+
+	expired := false
+	for {
+		select {
+		case repo, ok := <-scan:
+			if !ok {
+				msg.Done = true
+				return msg
+			}
+			msg.Repos = append(msg.Repos, repo)
+			if expired {
+				return msg
+			}
+		case <-timer.C:
+			if len(msg.Repos) > 0 {
+				return msg
+			}
+			expired = true
+		}
+	}
+}
+
+// clientForOwner returns the GitHub client for the host the owner lives on,
+// creating and caching a client the first time an enterprise host is used.
+func (m *Model) clientForOwner(owner string) (*github.Client, error) {
+	host := m.config.HostForOwner(owner)
+	if host == "" {
+		return m.githubClient, nil
+	}
+	if client, ok := m.githubClients[host]; ok {
+		return client, nil
+	}
+
+	client, err := github.NewClientForHost(host)
+	if err != nil {
+		return nil, err
+	}
+	if protocol, err := github.ParseProtocol(m.config.Protocol); err == nil {
+		client.SetProtocol(protocol)
+	}
+	if repoLayout, err := m.config.GetLayout(); err == nil {
+		client.SetLayout(repoLayout)
+	}
+	m.githubClients[client.Host()] = client
+	return client, nil
+}
+
+// cachedClientForOwner returns the owner's client if one has been created,
+// falling back to the default client. It never creates clients, so it is
+// safe to call from View.
+func (m Model) cachedClientForOwner(owner string) *github.Client {
+	if host := m.config.HostForOwner(owner); host != "" {
+		if client, ok := m.githubClients[host]; ok {
+			return client
+		}
+	}
+	return m.githubClient
+}
+
 // loadLocalReposForTemplateTargets loads local repositories as potential template targets.
 func (m *Model) loadLocalReposForTemplateTargets() tea.Cmd {
 	return func() tea.Msg {
 		scanner := local.NewScanner()
+		scanner.SetScanOptions(m.config.ScanOptions())
+		scanner.SetCache(m.scanCache)
 		repos, err := scanner.ScanMultipleDirectories(m.config.SourceDirs)
 		if err != nil {
 			return LoadErrorMsg{Err: err}
 		}
 
-		// Extract paths
-		paths := make([]string, len(repos))
-		for i, repo := range repos {
-			paths[i] = repo.Path
+		// Extract paths, skipping bare repositories as they have no working
+		// tree to sync templates into
+		paths := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			if repo.Kind != local.KindBare {
+				paths = append(paths, repo.Path)
+			}
 		}
 
 		return TemplateTargetsLoadedMsg{Paths: paths}
@@ -253,6 +393,20 @@ type TemplateTargetsLoadedMsg struct {
 	Paths []string
 }
 
+// isBackgroundMsg reports whether msg carries the result of a scan, load or
+// sync running in the background.
+func isBackgroundMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case ReposLoadedMsg, OrgsLoadedMsg, LocalReposFoundMsg, LoadErrorMsg,
+		SyncProgressMsg, SyncCompleteMsg, RepoExistsMsg,
+		TemplateTargetsLoadedMsg, TemplateTreeLoadedMsg, TemplatePlanLoadedMsg,
+		TemplateDriftLoadedMsg, TemplateSyncProgressMsg, TemplateConflictMsg,
+		TemplateSyncCompleteMsg:
+		return true
+	}
+	return false
+}
+
 // Update handles messages and updates the model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.quitting {
@@ -267,27 +421,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.calculateLayoutHeights()
 		m.settings.SetSize(msg.Width, msg.Height)
+		m.exec.SetSize(msg.Width, msg.Height)
+		m.templateConflict.SetSize(msg.Width, msg.Height)
+		m.templateDrift.SetSize(msg.Width, msg.Height)
 		return m, nil
 	}
 
+	// Command results and spinner ticks go straight to their owners so an
+	// overlay opened on top can't stall them
+	switch msg := msg.(type) {
+	case ExecResultMsg:
+		var cmd tea.Cmd
+		m.exec, cmd = m.exec.Update(msg)
+		return m, cmd
+
+	case spinner.TickMsg:
+		var execCmd, progressCmd tea.Cmd
+		m.exec, execCmd = m.exec.Update(msg)
+		if m.syncing {
+			m.progress, progressCmd = m.progress.Update(msg)
+		}
+		return m, tea.Batch(execCmd, progressCmd)
+	}
+
+	// Scan, load and sync results skip the overlays; the goroutines producing
+	// them wait for the model to ask for the next one
+	background := isBackgroundMsg(msg)
+
 	// Handle settings overlay
-	if m.showSettings {
+	if m.showSettings && !background {
 		return m.updateSettings(msg)
 	}
 
 	// Handle repository exists dialog
-	if m.repoExistsDialog.IsVisible() {
+	if m.repoExistsDialog.IsVisible() && !background {
 		return m.updateRepoExistsDialog(msg)
 	}
 
+	// Handle sync confirmation dialog
+	if m.syncConfirm.IsVisible() && !background {
+		var cmd tea.Cmd
+		m.syncConfirm, cmd = m.syncConfirm.Update(msg)
+		return m, cmd
+	}
+
+	// Handle exec dialog
+	if m.exec.IsVisible() && !background {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		var cmd tea.Cmd
+		m.exec, cmd = m.exec.Update(msg)
+		return m, cmd
+	}
+
 	// Handle owner selector
-	if m.ownerSelector.IsExpanded() {
+	if m.ownerSelector.IsExpanded() && !background {
 		return m.updateOwnerSelector(msg)
 	}
 
 	// Handle template selector popup (only in template mode)
 	// BUT: Always allow global quit commands and template messages to pass through
-	if m.mode == ModeTemplate && m.templateSelector != nil && m.templateSelector.IsVisible() {
+	if m.mode == ModeTemplate && m.templateSelector != nil && m.templateSelector.IsVisible() && !background {
 		// Check for quit commands first - these should always work
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			switch keyMsg.String() {
@@ -309,12 +505,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	// Handle template conflict dialog
-	if m.templateConflict.IsVisible() {
+	if m.templateConflict.IsVisible() && !background {
 		return m.updateTemplateConflict(msg)
 	}
 
+	// Handle template drift view; the check result still reaches the model
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.templateDrift.IsVisible() {
+		if keyMsg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		var cmd tea.Cmd
+		m.templateDrift, cmd = m.templateDrift.Update(msg)
+		return m, cmd
+	}
+
 	// Handle help overlay
-	if m.showHelp {
+	if m.showHelp && !background {
 		if msg, ok := msg.(tea.KeyMsg); ok {
 			if msg.String() == "?" || msg.String() == "esc" {
 				m.showHelp = false
@@ -346,11 +553,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case "f":
+			// Cycle between all, owned and collaborator repositories
+			if m.mode == ModePersonal && !m.syncing && !m.list.IsSearching() {
+				m.personalView = m.personalView.Next()
+				m.list.SetLoading(true)
+				return m, m.loadRepositories()
+			}
+
+		case "v":
+			// Cycle the visibility filter
+			if m.mode == ModePersonal && !m.syncing && !m.list.IsSearching() {
+				m.visibility = nextVisibility(m.visibility)
+				m.list.SetLoading(true)
+				return m, m.loadRepositories()
+			}
+
+		case "p":
+			// Fetch and fast-forward the selected repositories
+			if m.mode != ModeTemplate && !m.syncing && !m.list.IsSearching() && m.list.GetSelectedCount() > 0 {
+				return m.startPull()
+			}
+
+		case "x":
+			// Run a command in the selected local repositories
+			if m.mode == ModeLocal && !m.syncing && !m.list.IsSearching() && m.list.GetSelectedCount() > 0 {
+				m.exec.Show(m.list.GetSelectedItems(), m.config.GetJobs())
+				return m, nil
+			}
+
 		case "enter":
 			// Only handle enter for sync in non-template modes
 			// Template mode handles enter in updateTemplateMode()
-			if m.mode != ModeTemplate && !m.syncing {
-				return m.startSync()
+			if m.mode != ModeTemplate && !m.syncing && m.list.GetSelectedCount() > 0 {
+				m.syncConfirm.Show(m.list.GetSelectedCount(), m.config.Clone)
+				return m, nil
 			}
 		}
 	}
@@ -417,9 +654,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case TemplateTargetsSelectedMsg:
 		return m.handleTemplateTargetsSelected(msg)
 
+	case TemplateConflictMsg:
+		// The sync goroutine waits for the answer before sending more progress
+		m.templateConflict.Show(msg)
+		return m, m.waitForTemplateSyncProgress()
+
 	case TemplateConflictResponseMsg:
 		return m.handleTemplateConflictResponse(msg)
 
+	case TemplateDriftLoadedMsg:
+		m.templateDrift.SetStatuses(msg.Statuses)
+		return m, nil
+
+	case TemplatePlanLoadedMsg:
+		// Ignore plans the user backed out of
+		if m.templateState.Step == StepReview {
+			m.templateReview.SetPlan(msg.Changes, msg.Err)
+		}
+		return m, nil
+
 	case TemplateSyncProgressMsg:
 		// Update progress display
 		m.templateState.SyncProgress.Current = msg.Current
@@ -432,14 +685,71 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case TemplateSyncCompleteMsg:
 		m.templateSyncing = false
 		m.templateState.SyncedCount = msg.Synced
+		m.templateState.UnchangedCount = msg.Unchanged
 		m.templateState.SkippedCount = msg.Skipped
+		m.templateState.MergedCount = msg.Merged
+		m.templateState.ConflictedCount = msg.Conflicted
 		m.templateState.ErrorCount = msg.Errors
 		m.templateState.Step = StepComplete
 		// Clean up the progress channel
 		m.templateSyncProgressChan = nil
+		m.templateConflictResponses = nil
 		return m, nil
 	}
 
+	// Route repository sync and local scan messages regardless of the active
+	// tab so work started on another tab keeps draining its channel
+	switch msg := msg.(type) {
+	case SyncProgressMsg:
+		var cmd tea.Cmd
+		m.progress, cmd = m.progress.Update(msg)
+		return m, cmd
+
+	case SyncCompleteMsg:
+		var cmd tea.Cmd
+		m.progress, cmd = m.progress.Update(msg)
+		m.syncing = false
+		return m, cmd
+
+	case LocalReposFoundMsg:
+		// Let scans started before a tab switch finish without touching the list
+		if m.mode != ModeLocal || (!msg.Reset && msg.Scan != m.localScan) {
+			if msg.Done {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				for range msg.Scan {
+				}
+				return nil
+			}
+		}
+
+		if msg.Reset {
+			m.localScan = msg.Scan
+			m.localSeen = make(map[string]bool)
+			m.list.SetItems(FromLocalRepos(msg.Repos))
+		} else {
+			m.list.MergeItems(FromLocalRepos(msg.Repos))
+		}
+		if !msg.Cached {
+			for _, repo := range msg.Repos {
+				m.localSeen[repo.Path] = true
+			}
+		}
+		m.list.SetLoading(false)
+		m.list.SetScanning(!msg.Done)
+		if msg.Done {
+			// Drop cached repositories the scan didn't find again
+			seen := m.localSeen
+			m.list.RetainItems(func(id string) bool { return seen[id] })
+			m.localScan, m.localSeen = nil, nil
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			return readLocalScan(msg.Scan, false)
+		}
+	}
+
 	// Handle template mode after template workflow messages are processed
 	if m.mode == ModeTemplate {
 		return m.updateTemplateMode(msg)
@@ -455,12 +765,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ReposLoadedMsg:
 		m.list.SetItems(msg.Items)
 		m.list.SetLoading(false)
+		m.list.SetScanning(false)
 		return m, nil
 
 	case LoadErrorMsg:
 		m.list.SetError(msg.Err)
 		return m, nil
 
+	case SyncConfirmMsg:
+		if !msg.Confirmed {
+			return m, nil
+		}
+		return m.startSync(msg.Options)
+
 	case SelectOwnerMsg:
 		m.owner = msg.Owner
 		m.list.SetLoading(true)
@@ -473,13 +790,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.loadRepositories()
 
-	case SyncCompleteMsg:
-		var cmd tea.Cmd
-		m.progress, cmd = m.progress.Update(msg)
-		m.syncing = false
-		cmds = append(cmds, cmd)
-		return m, tea.Batch(cmds...)
-
 	case RepoExistsMsg:
 		// Show the dialog when a repository exists
 		m.repoExistsDialog.Show(msg.RepoName, msg.RepoPath, msg.RepoIndex, msg.Mode)
@@ -525,6 +835,18 @@ func (m Model) updateSettings(msg tea.Msg) (tea.Model, tea.Cmd) {
 				persistedCfg = &config.PersistedConfig{}
 			}
 			m.config = m.config.MergeWithPersisted(persistedCfg)
+			// Clients are shared, so they only pick up settings here
+			protocol, protocolErr := github.ParseProtocol(m.config.Protocol)
+			repoLayout, layoutErr := m.config.GetLayout()
+			for _, client := range m.githubClients {
+				if protocolErr == nil {
+					client.SetProtocol(protocol)
+				}
+				if layoutErr == nil {
+					client.SetLayout(repoLayout)
+				}
+			}
+			m.ownerSelector.SetOwnerHosts(m.config.OwnerHosts)
 		}
 		return m, nil
 	}
@@ -548,8 +870,9 @@ func (m Model) updateRepoExistsDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// startSync initiates the sync process.
-func (m Model) startSync() (tea.Model, tea.Cmd) {
+// startSync initiates the sync process with the clone options confirmed for
+// this batch. Per-repository overrides from the config still apply.
+func (m Model) startSync(opts clone.Options) (tea.Model, tea.Cmd) {
 	selectedItems := m.list.GetSelectedItems()
 	if len(selectedItems) == 0 {
 		return m, nil
@@ -573,10 +896,85 @@ func (m Model) startSync() (tea.Model, tea.Cmd) {
 	mode := "github"
 	if m.mode == ModeLocal {
 		mode = "local"
+	} else {
+		client, err := m.clientForOwner(m.owner)
+		if err != nil {
+			m.syncing = true
+			return m, func() tea.Msg {
+				return SyncCompleteMsg{
+					Results: []SyncResult{{
+						Repo:    m.owner,
+						Success: false,
+						Error:   fmt.Errorf("failed to create GitHub client: %w", err),
+					}},
+				}
+			}
+		}
+		m.progress.SetClient(client)
+	}
+
+	repoLayout, err := m.config.GetLayout()
+	var copyOpts local.CopyOptions
+	if err == nil {
+		copyOpts, err = m.config.CopyOptions()
+	}
+	if err != nil {
+		m.syncing = true
+		return m, func() tea.Msg {
+			return SyncCompleteMsg{
+				Results: []SyncResult{{Repo: "config", Success: false, Error: err}},
+			}
+		}
+	}
+
+	batchCfg := *m.config
+	batchCfg.Clone = opts
+
+	m.syncing = true
+	return m, m.progress.Start(selectedItems, SyncOptions{
+		TargetDir: targetDir,
+		Mode:      mode,
+		Jobs:      m.config.GetJobs(),
+		Clone:     batchCfg.CloneOptionsFor,
+		Layout:    repoLayout,
+		Copy:      copyOpts,
+	})
+}
+
+// startPull fetches the selected repositories and fast-forwards the ones
+// that can be updated safely. In GitHub modes the clones in the target
+// directory are pulled.
+func (m Model) startPull() (tea.Model, tea.Cmd) {
+	selectedItems := m.list.GetSelectedItems()
+	if len(selectedItems) == 0 {
+		return m, nil
+	}
+
+	opts := SyncOptions{Mode: "local", Jobs: m.config.GetJobs()}
+	if m.mode != ModeLocal {
+		targetDir, err := m.config.GetTargetDir()
+		if err == nil {
+			opts.Layout, err = m.config.GetLayout()
+		}
+		var client *github.Client
+		if err == nil {
+			client, err = m.clientForOwner(m.owner)
+		}
+		if err != nil {
+			m.syncing = true
+			return m, func() tea.Msg {
+				return SyncCompleteMsg{
+					Results: []SyncResult{{Repo: "config", Success: false, Error: err}},
+				}
+			}
+		}
+		m.progress.SetClient(client)
+		opts.Mode = "github"
+		opts.TargetDir = targetDir
 	}
 
 	m.syncing = true
-	return m, m.progress.Start(selectedItems, targetDir, mode)
+	return m, m.progress.StartPull(selectedItems, opts)
 }
 
 // calculateLayoutHeights calculates the fixed heights of each layout component.
@@ -650,7 +1048,7 @@ func (m Model) updateTemplateMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			// Otherwise, go back one step or reset
-			if m.templateState.Step > StepSelectTemplate {
+			if m.templateState.Step > StepSelectTemplate && !m.templateSyncing {
 				m.templateState.PrevStep()
 				return m, nil
 			}
@@ -668,6 +1066,10 @@ func (m Model) updateTemplateMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.templateSelector.Show()
 					return m, nil
 				}
+			case "d":
+				if !m.templateSelector.IsVisible() {
+					return m.startTemplateDrift()
+				}
 			}
 		}
 		// Selector is now handled as an overlay in the main Update function
@@ -693,11 +1095,11 @@ func (m Model) updateTemplateMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case StepSelectTargets:
-		// Handle enter to start sync
+		// Handle enter to review the changes
 		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
 			if m.templateTargets != nil && m.templateTargets.HasSelections() {
 				m.templateState.TargetRepos = m.templateTargets.GetSelectedPaths()
-				return m.startTemplateSync()
+				return m.startTemplatePlan()
 			}
 		}
 
@@ -707,6 +1109,18 @@ func (m Model) updateTemplateMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+	case StepReview:
+		// Handle enter to sync the selected changes
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			if m.templateReview.Ready() {
+				return m.startTemplateSync()
+			}
+		}
+
+		var cmd tea.Cmd
+		m.templateReview, cmd = m.templateReview.Update(msg)
+		cmds = append(cmds, cmd)
+
 	case StepSyncing:
 		// Syncing in progress - no user interaction except viewing
 		break
@@ -743,6 +1157,10 @@ func (m Model) updateTemplateSelector(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // updateTemplateConflict handles updates when template conflict dialog is visible.
 func (m Model) updateTemplateConflict(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+c" {
+		m.quitting = true
+		return m, tea.Quit
+	}
 	var cmd tea.Cmd
 	m.templateConflict, cmd = m.templateConflict.Update(msg)
 	return m, cmd
@@ -767,15 +1185,21 @@ func (m Model) handleTemplateRepoSelected(msg TemplateRepoSelectedMsg) (tea.Mode
 
 // loadGitHubTemplateTree loads the tree for a GitHub template repository.
 func (m *Model) loadGitHubTemplateTree(owner, repo string) tea.Cmd {
+	client, clientErr := m.clientForOwner(owner)
+
 	return func() tea.Msg {
+		if clientErr != nil {
+			return TemplateTreeLoadedMsg{Err: fmt.Errorf("failed to create GitHub client: %w", clientErr)}
+		}
+
 		// Get default branch
-		branch, err := m.githubClient.GetDefaultBranch(owner, repo)
+		branch, err := client.GetDefaultBranch(owner, repo)
 		if err != nil {
 			return TemplateTreeLoadedMsg{Err: fmt.Errorf("failed to get default branch: %w", err)}
 		}
 
 		// Get tree
-		treeResp, err := m.githubClient.GetRepoTree(owner, repo, branch)
+		treeResp, err := client.GetRepoTree(owner, repo, branch)
 		if err != nil {
 			return TemplateTreeLoadedMsg{Err: fmt.Errorf("failed to get repository tree: %w", err)}
 		}
@@ -909,14 +1333,15 @@ func (m Model) handleTemplateTreeLoaded(msg TemplateTreeLoadedMsg) (tea.Model, t
 		m.templateTree = NewTemplateTreeModelFromLocal(msg.Root, m.templateState.LocalTemplatePath)
 	} else {
 		// For GitHub, we need to load the tree properly
-		branch, err := m.githubClient.GetDefaultBranch(m.templateState.TemplateOwner, m.templateState.TemplateRepo)
+		client := m.cachedClientForOwner(m.templateState.TemplateOwner)
+		branch, err := client.GetDefaultBranch(m.templateState.TemplateOwner, m.templateState.TemplateRepo)
 		if err != nil {
 			m.templateSelector.SetError(fmt.Errorf("failed to get default branch: %w", err))
 			return m, nil
 		}
 		m.templateState.TemplateBranch = branch
 
-		treeResp, err := m.githubClient.GetRepoTree(m.templateState.TemplateOwner, m.templateState.TemplateRepo, branch)
+		treeResp, err := client.GetRepoTree(m.templateState.TemplateOwner, m.templateState.TemplateRepo, branch)
 		if err != nil {
 			m.templateSelector.SetError(err)
 			return m, nil
@@ -950,47 +1375,111 @@ func (m Model) handleTemplateTreeLoaded(msg TemplateTreeLoadedMsg) (tea.Model, t
 // handleTemplateTargetsSelected handles when target repositories are selected.
 func (m Model) handleTemplateTargetsSelected(msg TemplateTargetsSelectedMsg) (tea.Model, tea.Cmd) {
 	m.templateState.TargetRepos = msg.TargetPaths
-	return m.startTemplateSync()
+	return m.startTemplatePlan()
 }
 
-// handleTemplateConflictResponse handles user response to a conflict prompt.
+// handleTemplateConflictResponse passes the user's answer to a conflict
+// prompt to the waiting sync goroutine, which sets the engine's batch flags
+// for overwrite all and skip all itself.
 func (m Model) handleTemplateConflictResponse(msg TemplateConflictResponseMsg) (tea.Model, tea.Cmd) {
-	if m.templateEngine == nil {
+	if m.templateConflictResponses == nil {
 		return m, nil
 	}
 
+	action := template.ActionSkip
 	switch msg.Action {
+	case ConflictOverwrite:
+		action = template.ActionOverwrite
 	case ConflictOverwriteAll:
-		m.templateEngine.SetOverwriteAll(true)
+		action = template.ActionOverwriteAll
 	case ConflictSkipAll:
-		m.templateEngine.SetSkipAll(true)
+		action = template.ActionSkipAll
 	}
 
 	// Continue syncing
+	m.templateConflictResponses <- action
 	return m, nil
 }
 
-// startTemplateSync starts the template synchronization process.
-func (m Model) startTemplateSync() (tea.Model, tea.Cmd) {
+// startTemplateDrift checks the template locks of the local repositories
+// against their targets and templates.
+func (m Model) startTemplateDrift() (tea.Model, tea.Cmd) {
+	targets := m.localRepoPaths
+	m.templateDrift.Show(len(targets))
+
+	// The check runs in the background, so it gets its own client map
+	snapshot := m
+	snapshot.githubClients = maps.Clone(m.githubClients)
+	return m, func() tea.Msg {
+		return TemplateDriftLoadedMsg{Statuses: template.CheckDrift(targets, snapshot.templateEngineFor)}
+	}
+}
+
+// templateEngineFor creates a sync engine for a template source recorded in
+// a target's template lock.
+func (m Model) templateEngineFor(source, ref string) (*template.SyncEngine, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return template.NewLocalSyncEngine(source), nil
+	}
+
+	owner, repo, ok := strings.Cut(source, "/")
+	if !ok || owner == "" || repo == "" {
+		return nil, fmt.Errorf("template not found: %s", source)
+	}
+	client := m.cachedClientForOwner(owner)
+	if client == nil {
+		return nil, fmt.Errorf("no GitHub client for %s", owner)
+	}
+	if ref == "" {
+		var err error
+		if ref, err = client.GetDefaultBranch(owner, repo); err != nil {
+			return nil, fmt.Errorf("failed to get default branch: %w", err)
+		}
+	}
+	return template.NewSyncEngine(client, owner, repo, ref), nil
+}
+
+// startTemplatePlan computes the changes syncing the selected files into
+// the targets would make and shows them for review.
+func (m Model) startTemplatePlan() (tea.Model, tea.Cmd) {
 	if len(m.templateState.SelectedPaths) == 0 || len(m.templateState.TargetRepos) == 0 {
 		return m, nil
 	}
 
-	m.templateSyncing = true
-	m.templateState.Step = StepSyncing
+	m.templateState.Step = StepReview
+	m.templateReview.SetLoading()
 
 	// Create sync engine
 	if m.templateState.IsLocal {
 		m.templateEngine = template.NewLocalSyncEngine(m.templateState.LocalTemplatePath)
 	} else {
 		m.templateEngine = template.NewSyncEngine(
-			m.githubClient,
+			m.cachedClientForOwner(m.templateState.TemplateOwner),
 			m.templateState.TemplateOwner,
 			m.templateState.TemplateRepo,
 			m.templateState.TemplateBranch,
 		)
 	}
 
+	engine := m.templateEngine
+	files := m.templateState.SelectedPaths
+	targets := m.templateState.TargetRepos
+	return m, func() tea.Msg {
+		changes, err := engine.Plan(files, targets, nil)
+		return TemplatePlanLoadedMsg{Changes: changes, Err: err}
+	}
+}
+
+// startTemplateSync applies the reviewed changes.
+func (m Model) startTemplateSync() (tea.Model, tea.Cmd) {
+	if m.templateEngine == nil || !m.templateReview.Ready() {
+		return m, nil
+	}
+
+	m.templateSyncing = true
+	m.templateState.Step = StepSyncing
+	m.templateConflictResponses = make(chan template.ConflictAction, 1)
+
 	// Start sync
 	return m, m.runTemplateSync()
 }
@@ -1007,11 +1496,13 @@ func (m *Model) runTemplateSync() tea.Cmd {
 
 // executeTemplateSync runs the sync in a goroutine and sends progress to a shared channel.
 func (m *Model) executeTemplateSync() tea.Cmd {
+	responses := m.templateConflictResponses
 	return func() tea.Msg {
+		changes := m.templateReview.Changes()
+		approved := m.templateReview.Approved()
 		go func() {
-			results := m.templateEngine.SyncFiles(
-				m.templateState.SelectedPaths,
-				m.templateState.TargetRepos,
+			results := m.templateEngine.ApplyChanges(
+				changes,
 				func(progress template.SyncProgress) {
 					// Send progress update through the program
 					if m.templateSyncProgressChan != nil {
@@ -1024,26 +1515,38 @@ func (m *Model) executeTemplateSync() tea.Cmd {
 					}
 				},
 				func(conflict template.ConflictInfo) template.ConflictAction {
-					// For now, use batch flags or skip
-					if m.templateEngine.ShouldOverwriteAll() {
+					// Updates marked for overwriting in the review were approved there
+					if approved[filepath.Join(conflict.TargetRepo, conflict.FilePath)] {
 						return template.ActionOverwrite
 					}
-					if m.templateEngine.ShouldSkipAll() {
+					if m.templateSyncProgressChan == nil || responses == nil {
 						return template.ActionSkip
 					}
-					// Default: skip (in a real impl, would show dialog)
-					return template.ActionSkip
+
+					// Ask in the conflict dialog and wait for the answer
+					m.templateSyncProgressChan <- TemplateConflictMsg{
+						FilePath:       conflict.FilePath,
+						TargetRepoPath: conflict.TargetRepo,
+						Diff:           conflict.Diff,
+						TemplateSize:   conflict.TemplateSize,
+						LocalSize:      conflict.LocalSize,
+					}
+					return <-responses
 				},
 			)
 
-			synced, skipped, errors := template.GetSyncSummary(results)
+			synced, unchanged, skipped, errors := template.GetSyncSummary(results)
+			merges := template.SummarizeMerges(results)
 
 			// Send completion message
 			if m.templateSyncProgressChan != nil {
 				m.templateSyncProgressChan <- TemplateSyncCompleteMsg{
-					Synced:  synced,
-					Skipped: skipped,
-					Errors:  errors,
+					Synced:     synced,
+					Unchanged:  unchanged,
+					Skipped:    skipped,
+					Errors:     errors,
+					Merged:     merges[template.MergeClean] + merges[template.MergeMerged] + merges[template.MergeConflicted],
+					Conflicted: merges[template.MergeConflicted],
 				}
 				close(m.templateSyncProgressChan)
 			}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
+)
+
+// newTestModel creates a model with the sub-models Update needs and no
+// GitHub client.
+func newTestModel(mode ViewMode) Model {
+	return Model{
+		mode:             mode,
+		tabs:             NewTabBarModel(),
+		list:             NewListModel(),
+		progress:         NewInlineProgressModel(nil),
+		ownerSelector:    NewOwnerSelectorModel("user", nil),
+		repoExistsDialog: NewRepoExistsDialogModel(),
+		syncConfirm:      NewSyncConfirmModel(),
+		exec:             NewExecModel(),
+		templateConflict: NewTemplateConflictModel(),
+		templateDrift:    NewTemplateDriftModel(),
+	}
+}
+
+// TestLocalScanDrainedAfterTabSwitch tests that a local scan still running
+// when the user switches to the Template tab is read to the end.
+func TestLocalScanDrainedAfterTabSwitch(t *testing.T) {
+	scan := make(chan local.Repository)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, name := range []string{"b", "c"} {
+			scan <- local.Repository{Name: name, Path: "/" + name}
+		}
+		close(scan)
+	}()
+
+	var model tea.Model = newTestModel(ModeLocal)
+	model, _ = model.Update(LocalReposFoundMsg{Scan: scan, Reset: true, Repos: []local.Repository{{Name: "a", Path: "/a"}}})
+
+	m := model.(Model)
+	m.mode = ModeTemplate
+	model, cmd := m.Update(LocalReposFoundMsg{Scan: scan, Repos: []local.Repository{{Name: "b", Path: "/b"}}})
+	if cmd == nil {
+		t.Fatal("Expected a command draining the scan")
+	}
+	go cmd()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Scan was not drained after switching tabs")
+	}
+	if got := len(model.(Model).list.items); got != 1 {
+		t.Errorf("Expected the list to keep 1 item, got %d", got)
+	}
+}
@@ -16,6 +16,8 @@ package tui
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -28,6 +30,9 @@ type OwnerSelectorModel struct {
 	// Complex type
 	filterInput textinput.Model
 
+	// Map (8 bytes)
+	hosts map[string]string // Owner -> GitHub Enterprise Server host
+
 	// Slice (24 bytes)
 	orgs []string
 
@@ -44,8 +49,10 @@ type OwnerSelectorModel struct {
 	expanded bool
 }
 
-// NewOwnerSelectorModel creates a new owner selector model.
-func NewOwnerSelectorModel(username string) *OwnerSelectorModel {
+// NewOwnerSelectorModel creates a new owner selector model. Owners listed in
+// hosts are offered alongside the user's organizations and labelled with
+// their host.
+func NewOwnerSelectorModel(username string, hosts map[string]string) *OwnerSelectorModel {
 	ti := textinput.New()
 	ti.Placeholder = "Filter organizations..."
 	ti.CharLimit = 50
@@ -55,6 +62,7 @@ func NewOwnerSelectorModel(username string) *OwnerSelectorModel {
 		isOrg:         false,
 		username:      username,
 		orgs:          []string{},
+		hosts:         hosts,
 		expanded:      false,
 		filterInput:   ti,
 		cursor:        0,
@@ -67,6 +75,40 @@ func (m *OwnerSelectorModel) SetOrgs(orgs []string) {
 	m.orgs = orgs
 }
 
+// SetOwnerHosts sets the configured owner to host mapping.
+func (m *OwnerSelectorModel) SetOwnerHosts(hosts map[string]string) {
+	m.hosts = hosts
+}
+
+// hostFor returns the enterprise host configured for owner, if any.
+func (m *OwnerSelectorModel) hostFor(owner string) string {
+	for name, host := range m.hosts {
+		if strings.EqualFold(name, owner) {
+			return host
+		}
+	}
+	return ""
+}
+
+// owners returns the organizations plus any enterprise owners that are not
+// already listed, sorted so the list is stable between renders.
+func (m *OwnerSelectorModel) owners() []string {
+	owners := append([]string{}, m.orgs...)
+
+	extra := make([]string, 0, len(m.hosts))
+	for owner := range m.hosts {
+		if strings.EqualFold(owner, m.username) || slices.ContainsFunc(owners, func(o string) bool {
+			return strings.EqualFold(o, owner)
+		}) {
+			continue
+		}
+		extra = append(extra, owner)
+	}
+	sort.Strings(extra)
+
+	return append(owners, extra...)
+}
+
 // SetSelectedOwner sets the selected owner.
 func (m *OwnerSelectorModel) SetSelectedOwner(owner string, isOrg bool) {
 	m.selectedOwner = owner
@@ -174,12 +216,12 @@ func (m *OwnerSelectorModel) getFilteredItems() []string {
 
 	if filter == "" {
 		// No filter, return all
-		return append(items, m.orgs...)
+		return append(items, m.owners()...)
 	}
 
 	// Filter organizations
-	for _, org := range m.orgs {
-		if strings.Contains(strings.ToLower(org), filter) {
+	for _, org := range m.owners() {
+		if strings.Contains(strings.ToLower(org), filter) || strings.Contains(strings.ToLower(m.hostFor(org)), filter) {
 			items = append(items, org)
 		}
 	}
@@ -199,6 +241,10 @@ func (m *OwnerSelectorModel) View() string {
 		indicator = "▲"
 	}
 
+	if host := m.hostFor(m.selectedOwner); host != "" {
+		ownerType += ", " + host
+	}
+
 	label := fmt.Sprintf("Owner: %s (%s) %s", m.selectedOwner, ownerType, indicator)
 
 	if !m.expanded {
@@ -238,6 +284,9 @@ func (m *OwnerSelectorModel) View() string {
 		} else {
 			itemText = fmt.Sprintf("%s🏢 %s", prefix, item)
 		}
+		if host := m.hostFor(item); host != "" {
+			itemText += fmt.Sprintf(" (%s)", host)
+		}
 
 		var style lipgloss.Style
 		if isCursor {
@@ -264,6 +313,9 @@ func (m *OwnerSelectorModel) ViewInline() string {
 	}
 
 	label := fmt.Sprintf("%s %s", icon, m.selectedOwner)
+	if host := m.hostFor(m.selectedOwner); host != "" {
+		label += fmt.Sprintf(" (%s)", host)
+	}
 	return ownerInlineStyle.Render(label)
 }
 
@@ -15,6 +15,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,23 +26,55 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/MoshPitCodes/reposync/internal/clone"
 	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/layout"
 	"github.com/MoshPitCodes/reposync/internal/local"
+	"github.com/MoshPitCodes/reposync/internal/workerpool"
 )
 
+// syncTask describes a single repository queued for sync.
+type syncTask struct {
+	source   string // "owner/repo" for GitHub, source path for local
+	name     string
+	destPath string
+	index    int
+}
+
+// SyncOptions configures a sync started with InlineProgressModel.Start.
+type SyncOptions struct {
+	TargetDir string
+	Mode      string // "github" or "local"
+	Jobs      int
+	Clone     clone.OptionsFunc // Clone options per repository
+	Layout    layout.Layout     // Where repositories are placed in TargetDir
+	Copy      local.CopyOptions // How local repositories are copied
+}
+
 // InlineProgressModel manages inline progress display during sync.
 type InlineProgressModel struct {
 	// Complex types first
 	progressBar progress.Model
 	spinner     spinner.Model
 
+	// Channel shared by all workers for progress updates
+	progressChan chan tea.Msg
+
+	// Pointers (8 bytes each)
+	client    *github.Client
+	scanner   *local.Scanner
+	cloneOpts clone.OptionsFunc // Clone options per repository
+
 	// Slices (24 bytes each)
-	repos   []string
-	results []SyncResult
+	repos    []string
+	results  []SyncResult
+	existing []syncTask // Repositories waiting for a skip/refresh decision
+	active   []string   // Repositories currently being processed by a worker
 
 	// Strings (16 bytes each)
 	targetDir   string
 	mode        string // "github" or "local"
+	operation   string // "sync" or "pull"
 	currentRepo string
 
 	// Time (24 bytes each)
@@ -49,9 +82,9 @@ type InlineProgressModel struct {
 	endTime   time.Time
 
 	// Ints (8 bytes each)
-	current        int
-	total          int
-	pendingRepoIdx int
+	current int
+	total   int
+	jobs    int
 
 	// Bools (1 byte each, grouped together)
 	running        bool
@@ -62,7 +95,8 @@ type InlineProgressModel struct {
 }
 
 // NewInlineProgressModel creates a new inline progress model.
-func NewInlineProgressModel() *InlineProgressModel {
+// The client is used for GitHub clones; when nil a new client is created per sync.
+func NewInlineProgressModel(client *github.Client) *InlineProgressModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = spinnerStyle
@@ -70,6 +104,7 @@ func NewInlineProgressModel() *InlineProgressModel {
 	p := progress.New(progress.WithDefaultGradient())
 
 	return &InlineProgressModel{
+		client:      client,
 		progressBar: p,
 		spinner:     s,
 		running:     false,
@@ -77,200 +112,309 @@ func NewInlineProgressModel() *InlineProgressModel {
 	}
 }
 
-// Start begins the sync process.
-func (m *InlineProgressModel) Start(repos []string, targetDir, mode string) tea.Cmd {
+// SetClient sets the GitHub client used for subsequent clones, e.g. when the
+// selected owner lives on a GitHub Enterprise Server host.
+func (m *InlineProgressModel) SetClient(client *github.Client) {
+	m.client = client
+}
+
+// Start begins the sync process. Repositories that do not exist at their
+// layout path are cloned/copied by up to opts.Jobs concurrent workers, while
+// existing ones are queued for the user to skip or refresh.
+func (m *InlineProgressModel) Start(repos []string, opts SyncOptions) tea.Cmd {
+	m.operation = "sync"
 	m.repos = repos
-	m.targetDir = targetDir
-	m.mode = mode
+	m.targetDir = opts.TargetDir
+	m.mode = opts.Mode
+	m.jobs = opts.Jobs
+	m.cloneOpts = opts.Clone
 	m.current = 0
 	m.total = len(repos)
 	m.running = true
 	m.complete = false
 	m.results = []SyncResult{}
+	m.existing = nil
+	m.active = nil
+	m.currentRepo = ""
 	m.startTime = time.Now()
-	m.pendingRepoIdx = 0
 	m.skipAll = false
 	m.refreshAll = false
 	m.waitingForUser = false
 
+	// Every repository produces at most a start and a finish message, so the
+	// buffer guarantees that workers and Update never block when sending.
+	m.progressChan = make(chan tea.Msg, 2*len(repos)+1)
+
+	// Validate target directory
+	if m.targetDir == "" {
+		return func() tea.Msg {
+			return SyncCompleteMsg{
+				Results: []SyncResult{{Repo: "sync", Success: false, Error: fmt.Errorf("target directory not set")}},
+			}
+		}
+	}
+
+	m.scanner = local.NewScanner()
+	m.scanner.SetLayout(opts.Layout)
+	m.scanner.SetCopyOptions(opts.Copy)
+	if m.mode == "github" {
+		client, err := m.githubClient(opts.Layout)
+		if err != nil {
+			return func() tea.Msg {
+				return SyncCompleteMsg{
+					Results: []SyncResult{{Repo: "sync", Success: false, Error: fmt.Errorf("failed to create GitHub client: %w", err)}},
+				}
+			}
+		}
+		m.client = client
+	}
+
+	var pending []syncTask
+	for i, repo := range repos {
+		task, err := m.newSyncTask(i, repo)
+		if err != nil {
+			m.emit(SyncProgressMsg{Repo: repo, Status: workerpool.StatusFailed, Err: err})
+			continue
+		}
+
+		// Existing repositories need a decision from the user
+		if _, err := os.Stat(task.destPath); err == nil {
+			m.existing = append(m.existing, task)
+			continue
+		}
+
+		pending = append(pending, task)
+	}
+
 	return tea.Batch(
 		m.spinner.Tick,
-		m.syncNextRepo(),
+		m.syncTasks(pending),
+		m.nextExisting(),
+		m.waitForProgress(),
 	)
 }
 
-// syncNextRepo syncs the next repository in the queue.
-func (m *InlineProgressModel) syncNextRepo() tea.Cmd {
-	return func() tea.Msg {
-		// Check if we're done
-		if m.pendingRepoIdx >= len(m.repos) {
-			return SyncCompleteMsg{Results: m.results}
+// StartPull fetches the selected repositories and fast-forwards the ones that
+// are clean and tracking an upstream, using up to opts.Jobs workers. In GitHub
+// mode the clones in the target directory are pulled; in local mode the
+// selected repositories themselves. Clone options are ignored.
+func (m *InlineProgressModel) StartPull(repos []string, opts SyncOptions) tea.Cmd {
+	m.operation = "pull"
+	m.repos = repos
+	m.targetDir = opts.TargetDir
+	m.mode = opts.Mode
+	m.jobs = opts.Jobs
+	m.current = 0
+	m.total = len(repos)
+	m.running = true
+	m.complete = false
+	m.results = []SyncResult{}
+	m.existing = nil
+	m.active = nil
+	m.currentRepo = ""
+	m.startTime = time.Now()
+	m.waitingForUser = false
+	m.progressChan = make(chan tea.Msg, 2*len(repos)+1)
+
+	m.scanner = local.NewScanner()
+	m.scanner.SetLayout(opts.Layout)
+	if m.mode == "github" {
+		client, err := m.githubClient(opts.Layout)
+		if err != nil {
+			return func() tea.Msg {
+				return SyncCompleteMsg{
+					Results: []SyncResult{{Repo: "pull", Success: false, Error: fmt.Errorf("failed to create GitHub client: %w", err)}},
+				}
+			}
+		}
+		m.client = client
+	}
+
+	var pending []local.Repository
+	for i, repo := range repos {
+		if m.mode == "local" {
+			pending = append(pending, local.Repository{Name: filepath.Base(repo), Path: repo})
+			continue
 		}
 
-		if m.mode == "github" {
-			return m.syncNextGitHubRepo()
+		task, err := m.newSyncTask(i, repo)
+		if err != nil {
+			m.emit(SyncProgressMsg{Repo: repo, Status: workerpool.StatusFailed, Err: err})
+			continue
+		}
+		if !m.scanner.IsGitRepository(task.destPath) {
+			m.emit(SyncProgressMsg{Repo: task.name, Status: workerpool.StatusFailed, Err: &local.SkipError{Reason: "not cloned"}})
+			continue
 		}
-		return m.syncNextLocalRepo()
+		pending = append(pending, local.Repository{Name: task.name, Path: task.destPath})
 	}
+
+	jobs := m.jobs
+	scanner := m.scanner
+	pull := func() tea.Msg {
+		scanner.PullRepos(pending, jobs, m.report)
+		return nil
+	}
+
+	return tea.Batch(m.spinner.Tick, pull, m.waitForProgress())
 }
 
-// syncNextGitHubRepo synchronizes the next GitHub repository.
-func (m *InlineProgressModel) syncNextGitHubRepo() tea.Msg {
-	// Validate target directory
-	if m.targetDir == "" {
-		return SyncCompleteMsg{
-			Results: []SyncResult{{Repo: "sync", Success: false, Error: fmt.Errorf("target directory not set")}},
+// newSyncTask resolves the repository name and destination path for a selected item.
+func (m *InlineProgressModel) newSyncTask(index int, repo string) (syncTask, error) {
+	task := syncTask{source: repo, index: index}
+
+	if m.mode == "github" {
+		parts := strings.Split(repo, "/")
+		if len(parts) != 2 {
+			return task, fmt.Errorf("invalid repository format (expected owner/repo, got %q)", repo)
 		}
+		task.name = parts[1]
+		task.destPath = m.client.RepoPath(m.targetDir, github.Repository{Name: task.name, FullName: repo})
+	} else {
+		task.name = filepath.Base(repo)
+		task.destPath = m.scanner.DestPath(repo, m.targetDir)
 	}
 
-	client, err := github.NewClient()
-	if err != nil {
-		return SyncCompleteMsg{
-			Results: []SyncResult{{Repo: "sync", Success: false, Error: fmt.Errorf("failed to create GitHub client: %w", err)}},
-		}
+	return task, nil
+}
+
+// emit queues a message on the progress channel.
+func (m *InlineProgressModel) emit(msg tea.Msg) {
+	m.progressChan <- msg
+}
+
+// report converts a worker pool event into a progress message on the channel.
+func (m *InlineProgressModel) report(event workerpool.Event) {
+	m.emit(SyncProgressMsg{
+		Repo:   event.Name,
+		Status: event.Status,
+		Err:    event.Err,
+		Detail: event.Detail,
+	})
+}
+
+// waitForProgress waits for the next message from the sync workers.
+func (m *InlineProgressModel) waitForProgress() tea.Cmd {
+	ch := m.progressChan
+	return func() tea.Msg {
+		return <-ch
 	}
+}
 
-	fullName := m.repos[m.pendingRepoIdx]
-	parts := strings.Split(fullName, "/")
-	if len(parts) != 2 {
-		m.results = append(m.results, SyncResult{
-			Repo:    fullName,
-			Success: false,
-			Error:   fmt.Errorf("invalid repository format (expected owner/repo, got %q)", fullName),
-		})
-		m.pendingRepoIdx++
-		m.current++
-		return m.syncNextRepo()()
-	}
-
-	owner, repoName := parts[0], parts[1]
-	repoPath := filepath.Join(m.targetDir, repoName)
-
-	// Check if repository already exists
-	if _, err := os.Stat(repoPath); err == nil {
-		// Repository exists
-		if m.skipAll {
-			// Skip this repository
-			m.results = append(m.results, SyncResult{
-				Repo:    repoName,
-				Success: true,
-				Error:   nil,
-			})
-			m.pendingRepoIdx++
-			m.current++
-			return m.syncNextRepo()()
-		} else if m.refreshAll {
-			// Refresh this repository
-			err := client.RefreshRepo(repoPath)
-			m.results = append(m.results, SyncResult{
-				Repo:    repoName,
-				Success: err == nil,
-				Error:   err,
-			})
-			m.pendingRepoIdx++
-			m.current++
-			return m.syncNextRepo()()
-		} else {
-			// Prompt user
-			return RepoExistsMsg{
-				RepoName:  repoName,
-				RepoPath:  repoPath,
-				RepoIndex: m.pendingRepoIdx,
-				Mode:      "github",
+// syncTasks clones (GitHub) or copies (local) repositories using the worker pool.
+func (m *InlineProgressModel) syncTasks(tasks []syncTask) tea.Cmd {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	targetDir := m.targetDir
+	jobs := m.jobs
+	opts := m.cloneOpts
+	client := m.client
+	scanner := m.scanner
+
+	if m.mode == "github" {
+		return func() tea.Msg {
+			repos := make([]github.Repository, len(tasks))
+			for i, task := range tasks {
+				repos[i] = github.Repository{Name: task.name, FullName: task.source}
 			}
+			client.CloneRepos(repos, targetDir, jobs, opts, m.report)
+			return nil
 		}
 	}
 
-	// Repository doesn't exist - clone it
-	err = client.CloneRepo(owner, repoName, m.targetDir)
-	m.results = append(m.results, SyncResult{
-		Repo:    repoName,
-		Success: err == nil,
-		Error:   err,
-	})
-	m.pendingRepoIdx++
-	m.current++
+	return func() tea.Msg {
+		repos := make([]local.Repository, len(tasks))
+		for i, task := range tasks {
+			repos[i] = local.Repository{Name: task.name, Path: task.source}
+		}
+		scanner.CopyRepos(repos, targetDir, jobs, opts, m.report)
+		return nil
+	}
+}
 
-	return m.syncNextRepo()()
+// githubClient returns the shared GitHub client, which already carries the
+// configured layout, or creates one with repoLayout if none was provided.
+func (m *InlineProgressModel) githubClient(repoLayout layout.Layout) (*github.Client, error) {
+	if m.client != nil {
+		return m.client, nil
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	client.SetLayout(repoLayout)
+	return client, nil
 }
 
-// syncNextLocalRepo synchronizes the next local repository.
-func (m *InlineProgressModel) syncNextLocalRepo() tea.Msg {
-	// Validate target directory
-	if m.targetDir == "" {
-		return SyncCompleteMsg{
-			Results: []SyncResult{{Repo: "sync", Success: false, Error: fmt.Errorf("target directory not set")}},
-		}
+// refreshTasks runs git pull on existing repositories using the worker pool.
+func (m *InlineProgressModel) refreshTasks(tasks []syncTask) tea.Cmd {
+	if len(tasks) == 0 {
+		return nil
 	}
 
-	scanner := local.NewScanner()
-	repoPath := m.repos[m.pendingRepoIdx]
-	repoName := repoPath
-	if parts := strings.Split(repoPath, "/"); len(parts) > 0 {
-		repoName = parts[len(parts)-1]
-	}
-
-	destPath := filepath.Join(m.targetDir, repoName)
-
-	// Check if destination already exists
-	if _, err := os.Stat(destPath); err == nil {
-		// Repository exists
-		if m.skipAll {
-			// Skip this repository
-			m.results = append(m.results, SyncResult{
-				Repo:    repoName,
-				Success: true,
-				Error:   nil,
-			})
-			m.pendingRepoIdx++
-			m.current++
-			return m.syncNextRepo()()
-		} else if m.refreshAll {
-			// Refresh this repository
-			err := scanner.RefreshRepo(destPath)
-			m.results = append(m.results, SyncResult{
-				Repo:    repoName,
-				Success: err == nil,
-				Error:   err,
-			})
-			m.pendingRepoIdx++
-			m.current++
-			return m.syncNextRepo()()
-		} else {
-			// Prompt user
-			return RepoExistsMsg{
-				RepoName:  repoName,
-				RepoPath:  destPath,
-				RepoIndex: m.pendingRepoIdx,
-				Mode:      "local",
-			}
+	jobs := m.jobs
+	refresh := m.scanner.RefreshRepo
+	if m.mode == "github" {
+		refresh = m.client.RefreshRepo
+	}
+
+	return func() tea.Msg {
+		workerpool.Run(jobs, len(tasks), func(i int) {
+			task := tasks[i]
+			m.report(workerpool.Started(task.index, task.name))
+			m.report(workerpool.Finished(task.index, task.name, refresh(task.destPath)))
+		})
+		return nil
+	}
+}
+
+// nextExisting handles the next repository that already exists in the target
+// directory, either by applying a batch decision or by prompting the user.
+func (m *InlineProgressModel) nextExisting() tea.Cmd {
+	if len(m.existing) == 0 {
+		return nil
+	}
+
+	if m.skipAll {
+		for _, task := range m.existing {
+			m.report(workerpool.Finished(task.index, task.name, nil))
 		}
+		m.existing = nil
+		return nil
 	}
 
-	// Repository doesn't exist - copy it
-	err := scanner.CopyRepo(repoPath, m.targetDir)
-	m.results = append(m.results, SyncResult{
-		Repo:    repoName,
-		Success: err == nil,
-		Error:   err,
-	})
-	m.pendingRepoIdx++
-	m.current++
+	if m.refreshAll {
+		tasks := m.existing
+		m.existing = nil
+		return m.refreshTasks(tasks)
+	}
 
-	return m.syncNextRepo()()
+	task := m.existing[0]
+	mode := m.mode
+	m.waitingForUser = true
+
+	return func() tea.Msg {
+		return RepoExistsMsg{
+			RepoName:  task.name,
+			RepoPath:  task.destPath,
+			RepoIndex: task.index,
+			Mode:      mode,
+		}
+	}
 }
 
 // Update handles messages for the progress component.
 func (m *InlineProgressModel) Update(msg tea.Msg) (*InlineProgressModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case SyncProgressMsg:
-		m.current = msg.Current
-		m.currentRepo = msg.Repo
-		return m, nil
+		return m.handleSyncProgress(msg)
 
 	case SyncCompleteMsg:
 		m.results = msg.Results
 		m.current = m.total
+		m.active = nil
 		m.running = false
 		m.complete = true
 		m.endTime = time.Now()
@@ -291,8 +435,56 @@ func (m *InlineProgressModel) Update(msg tea.Msg) (*InlineProgressModel, tea.Cmd
 	return m, nil
 }
 
+// handleSyncProgress records a worker update and completes the sync once
+// every repository has reported a result.
+func (m *InlineProgressModel) handleSyncProgress(msg SyncProgressMsg) (*InlineProgressModel, tea.Cmd) {
+	if msg.Status == workerpool.StatusStarted {
+		m.active = append(m.active, msg.Repo)
+		m.currentRepo = msg.Repo
+		return m, m.waitForProgress()
+	}
+
+	// Remove from the active set
+	for i, repo := range m.active {
+		if repo == msg.Repo {
+			m.active = append(m.active[:i], m.active[i+1:]...)
+			break
+		}
+	}
+
+	result := SyncResult{
+		Repo:    msg.Repo,
+		Success: msg.Status == workerpool.StatusSucceeded,
+		Detail:  msg.Detail,
+		Error:   msg.Err,
+	}
+	var skipErr *local.SkipError
+	if errors.As(msg.Err, &skipErr) {
+		result.Success, result.Skipped, result.Error = true, skipErr.Reason, nil
+	}
+	m.results = append(m.results, result)
+	m.current++
+
+	if m.current >= m.total {
+		results := m.results
+		return m, func() tea.Msg {
+			return SyncCompleteMsg{Results: results}
+		}
+	}
+
+	return m, m.waitForProgress()
+}
+
 // handleRepoExistsResponse processes the user's response to a repository exists prompt.
 func (m *InlineProgressModel) handleRepoExistsResponse(msg RepoExistsResponseMsg) (*InlineProgressModel, tea.Cmd) {
+	if len(m.existing) == 0 {
+		return m, nil
+	}
+
+	task := m.existing[0]
+	m.existing = m.existing[1:]
+	m.waitingForUser = false
+
 	// Update flags based on action
 	switch msg.Action {
 	case ActionSkipAll:
@@ -301,62 +493,19 @@ func (m *InlineProgressModel) handleRepoExistsResponse(msg RepoExistsResponseMsg
 		m.refreshAll = true
 	}
 
-	// Get the repository info
-	var repoName, repoPath string
-	if m.mode == "github" {
-		fullName := m.repos[m.pendingRepoIdx]
-		parts := strings.Split(fullName, "/")
-		if len(parts) == 2 {
-			repoName = parts[1]
-			repoPath = filepath.Join(m.targetDir, repoName)
-		}
-	} else {
-		sourcePath := m.repos[m.pendingRepoIdx]
-		if parts := strings.Split(sourcePath, "/"); len(parts) > 0 {
-			repoName = parts[len(parts)-1]
-			repoPath = filepath.Join(m.targetDir, repoName)
-		}
-	}
-
 	// Handle the action
+	var cmd tea.Cmd
 	switch msg.Action {
 	case ActionSkip, ActionSkipAll:
 		// Skip this repository
-		m.results = append(m.results, SyncResult{
-			Repo:    repoName,
-			Success: true,
-			Error:   nil,
-		})
-		m.pendingRepoIdx++
-		m.current++
-		return m, m.syncNextRepo()
+		m.report(workerpool.Finished(task.index, task.name, nil))
 
 	case ActionRefresh, ActionRefreshAll:
 		// Refresh (git pull) this repository
-		var err error
-		if m.mode == "github" {
-			client, clientErr := github.NewClient()
-			if clientErr != nil {
-				err = clientErr
-			} else {
-				err = client.RefreshRepo(repoPath)
-			}
-		} else {
-			scanner := local.NewScanner()
-			err = scanner.RefreshRepo(repoPath)
-		}
-
-		m.results = append(m.results, SyncResult{
-			Repo:    repoName,
-			Success: err == nil,
-			Error:   err,
-		})
-		m.pendingRepoIdx++
-		m.current++
-		return m, m.syncNextRepo()
+		cmd = m.refreshTasks([]syncTask{task})
 	}
 
-	return m, nil
+	return m, tea.Batch(cmd, m.nextExisting())
 }
 
 // View renders the inline progress bar.
@@ -376,47 +525,97 @@ func (m *InlineProgressModel) View() string {
 		b.WriteString(progressBarStyle.Render(m.progressBar.ViewAs(percent)))
 		b.WriteString(" " + progressTextStyle.Render(percentText))
 		b.WriteString(" • ")
-		b.WriteString(progressTextStyle.Render(fmt.Sprintf("%d/%d synced", m.current, m.total)))
+		b.WriteString(progressTextStyle.Render(fmt.Sprintf("%d/%d %s", m.current, m.total, m.pastTense())))
 
 		if m.currentRepo != "" {
 			elapsed := time.Since(m.startTime)
 			b.WriteString(" • ")
 			b.WriteString(progressTextStyle.Render(formatDuration(elapsed)))
 		}
+
+		// Show repositories currently being processed by workers
+		if len(m.active) > 0 {
+			active := m.active
+			more := ""
+			if len(active) > 4 {
+				more = fmt.Sprintf(" (+%d more)", len(active)-4)
+				active = active[:4]
+			}
+			b.WriteString("\n")
+			verb := "Syncing"
+			if m.operation == "pull" {
+				verb = "Pulling"
+			}
+			b.WriteString(progressTextStyle.Render(fmt.Sprintf("%s: %s%s", verb, strings.Join(active, ", "), more)))
+		}
 	}
 
 	if m.complete {
 		// Show completion summary
 		successCount := 0
-		var failures []SyncResult
+		var failures, skipped, detailed []SyncResult
 		for _, result := range m.results {
-			if result.Success {
+			switch {
+			case result.Skipped != "":
+				skipped = append(skipped, result)
+			case result.Success:
 				successCount++
-			} else {
+				if result.Detail != "" {
+					detailed = append(detailed, result)
+				}
+			default:
 				failures = append(failures, result)
 			}
 		}
 
 		elapsed := m.endTime.Sub(m.startTime)
-		if successCount == m.total {
-			b.WriteString(RenderSuccess(fmt.Sprintf("✓ %d/%d synced • %s", successCount, m.total, formatDuration(elapsed))))
-		} else {
-			failCount := m.total - successCount
-			b.WriteString(RenderWarning(fmt.Sprintf("⚠ %d succeeded, %d failed • %s", successCount, failCount, formatDuration(elapsed))))
-
-			// Show error details for failed repos
-			if len(failures) > 0 {
-				b.WriteString("\n\n")
-				b.WriteString(RenderError("Failed repositories:"))
+		switch {
+		case successCount == m.total:
+			b.WriteString(RenderSuccess(fmt.Sprintf("✓ %d/%d %s • %s", successCount, m.total, m.pastTense(), formatDuration(elapsed))))
+		case len(failures) == 0:
+			b.WriteString(RenderWarning(fmt.Sprintf("⚠ %d %s, %d skipped • %s", successCount, m.pastTense(), len(skipped), formatDuration(elapsed))))
+		default:
+			summary := fmt.Sprintf("⚠ %d succeeded, %d failed", successCount, len(failures))
+			if len(skipped) > 0 {
+				summary += fmt.Sprintf(", %d skipped", len(skipped))
+			}
+			b.WriteString(RenderWarning(summary + " • " + formatDuration(elapsed)))
+		}
+
+		// Show what mirror copies carried over
+		if len(detailed) > 0 {
+			b.WriteString("\n\n")
+			b.WriteString(RenderSuccess("Carried over:"))
+			b.WriteString("\n")
+			for _, result := range detailed {
+				b.WriteString(RenderSuccess(fmt.Sprintf("  • %s: %s", result.Repo, result.Detail)))
 				b.WriteString("\n")
-				for _, failure := range failures {
-					errMsg := "unknown error"
-					if failure.Error != nil {
-						errMsg = failure.Error.Error()
-					}
-					b.WriteString(RenderError(fmt.Sprintf("  • %s: %s", failure.Repo, errMsg)))
-					b.WriteString("\n")
+			}
+		}
+
+		// Show why pulls left repositories unchanged
+		if len(skipped) > 0 {
+			b.WriteString("\n\n")
+			b.WriteString(RenderWarning("Skipped repositories:"))
+			b.WriteString("\n")
+			for _, result := range skipped {
+				b.WriteString(RenderWarning(fmt.Sprintf("  • %s: %s", result.Repo, result.Skipped)))
+				b.WriteString("\n")
+			}
+		}
+
+		// Show error details for failed repos
+		if len(failures) > 0 {
+			b.WriteString("\n\n")
+			b.WriteString(RenderError("Failed repositories:"))
+			b.WriteString("\n")
+			for _, failure := range failures {
+				errMsg := "unknown error"
+				if failure.Error != nil {
+					errMsg = failure.Error.Error()
 				}
+				b.WriteString(RenderError(fmt.Sprintf("  • %s: %s", failure.Repo, errMsg)))
+				b.WriteString("\n")
 			}
 		}
 	}
@@ -447,6 +646,16 @@ func (m *InlineProgressModel) Reset() {
 	m.total = 0
 	m.currentRepo = ""
 	m.results = []SyncResult{}
+	m.existing = nil
+	m.active = nil
+}
+
+// pastTense describes finished repositories for the current operation.
+func (m *InlineProgressModel) pastTense() string {
+	if m.operation == "pull" {
+		return "pulled"
+	}
+	return "synced"
 }
 
 // formatDuration formats a duration into a human-readable string.
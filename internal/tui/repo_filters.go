@@ -0,0 +1,74 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import "github.com/MoshPitCodes/reposync/internal/github"
+
+// PersonalRepoView selects which of the authenticated user's repositories the
+// Personal tab lists.
+type PersonalRepoView int
+
+const (
+	PersonalViewAll          PersonalRepoView = iota // Owned, collaborator and org member repos
+	PersonalViewOwned                                // Repos owned by the user
+	PersonalViewCollaborator                         // Repos the user was invited to
+)
+
+// String returns the string representation of the personal view.
+func (v PersonalRepoView) String() string {
+	switch v {
+	case PersonalViewAll:
+		return "All"
+	case PersonalViewOwned:
+		return "Owned"
+	case PersonalViewCollaborator:
+		return "Collaborator"
+	default:
+		return "Unknown"
+	}
+}
+
+// Next returns the view that follows v, wrapping around.
+func (v PersonalRepoView) Next() PersonalRepoView {
+	return (v + 1) % 3
+}
+
+// Affiliations returns the API affiliations listed by the view.
+func (v PersonalRepoView) Affiliations() []string {
+	switch v {
+	case PersonalViewOwned:
+		return []string{github.AffiliationOwner}
+	case PersonalViewCollaborator:
+		return []string{github.AffiliationCollaborator}
+	default:
+		return []string{
+			github.AffiliationOwner,
+			github.AffiliationCollaborator,
+			github.AffiliationOrganizationMember,
+		}
+	}
+}
+
+// nextVisibility cycles through the repository visibility filters.
+func nextVisibility(visibility string) string {
+	switch visibility {
+	case github.VisibilityAll, "":
+		return github.VisibilityPublic
+	case github.VisibilityPublic:
+		return github.VisibilityPrivate
+	default:
+		return github.VisibilityAll
+	}
+}
@@ -16,6 +16,8 @@ package tui
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -23,6 +25,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/MoshPitCodes/reposync/internal/config"
+	"github.com/MoshPitCodes/reposync/internal/github"
+	"github.com/MoshPitCodes/reposync/internal/layout"
+	"github.com/MoshPitCodes/reposync/internal/local"
 )
 
 // SettingsField represents a field in the settings form.
@@ -79,6 +84,55 @@ func NewSettingsModel(store *config.ConfigStore) *SettingsModel {
 			Placeholder: "your-github-username",
 			Help:        "Default GitHub user or organization",
 		},
+		{
+			Label:       "Parallel Jobs",
+			Key:         "jobs",
+			Value:       formatCount(persistedCfg.Jobs),
+			Placeholder: strconv.Itoa(config.DefaultJobs),
+			Help:        "Number of repositories to clone/copy in parallel",
+		},
+		{
+			Label:       "Clone Protocol",
+			Key:         "protocol",
+			Value:       persistedCfg.Protocol,
+			Placeholder: string(github.ProtocolSSH),
+			Help:        "ssh, https, or gh (HTTPS authenticated via gh auth token)",
+		},
+		{
+			Label:       "Workspace Layout",
+			Key:         "layout",
+			Value:       persistedCfg.Layout,
+			Placeholder: layout.DefaultPattern,
+			Help:        "Path template using {host}, {owner} and {repo}, e.g. {host}/{owner}/{repo}",
+		},
+		{
+			Label:       "Scan Max Depth",
+			Key:         "scan_max_depth",
+			Value:       formatCount(persistedCfg.ScanMaxDepth),
+			Placeholder: "unlimited",
+			Help:        "Directory levels below each source directory searched for repos",
+		},
+		{
+			Label:       "Scan Ignore Patterns",
+			Key:         "scan_ignore",
+			Value:       strings.Join(persistedCfg.ScanIgnore, ","),
+			Placeholder: "archive,clients/*/generated",
+			Help:        "Comma-separated gitignore-style patterns for directories to skip",
+		},
+		{
+			Label:       "Copy Mode",
+			Key:         "copy_mode",
+			Value:       persistedCfg.CopyMode,
+			Placeholder: string(local.DefaultCopyMode),
+			Help:        "clone, preserve (keep remotes and branches), or mirror (copy everything)",
+		},
+		{
+			Label:       "Enterprise Owner Hosts",
+			Key:         "owner_hosts",
+			Value:       formatOwnerHosts(persistedCfg.OwnerHosts),
+			Placeholder: "owner=ghe.example.com,other=ghe.example.com",
+			Help:        "Comma-separated owner=host pairs for GitHub Enterprise Server owners",
+		},
 	}
 
 	// Create text inputs for each field
@@ -153,8 +207,12 @@ func (m *SettingsModel) Update(msg tea.Msg) (*SettingsModel, tea.Cmd) {
 }
 
 // Save saves the current settings to the config store.
+// Values not editable in the settings form (e.g. recent owners) are preserved.
 func (m *SettingsModel) Save() error {
-	persistedCfg := &config.PersistedConfig{}
+	persistedCfg, err := m.store.Load()
+	if err != nil {
+		persistedCfg = &config.PersistedConfig{}
+	}
 
 	for i, field := range m.fields {
 		value := m.inputs[i].Value()
@@ -162,11 +220,72 @@ func (m *SettingsModel) Save() error {
 		case "target_dir":
 			persistedCfg.TargetDir = value
 		case "source_dirs":
+			persistedCfg.SourceDirs = nil
 			if value != "" {
 				persistedCfg.SourceDirs = strings.Split(value, ":")
 			}
 		case "default_owner":
 			persistedCfg.DefaultOwner = value
+		case "jobs":
+			persistedCfg.Jobs = 0
+			if value != "" {
+				jobs, err := strconv.Atoi(value)
+				if err != nil || jobs < 1 {
+					return fmt.Errorf("invalid number of parallel jobs: %q", value)
+				}
+				persistedCfg.Jobs = jobs
+			}
+		case "protocol":
+			persistedCfg.Protocol = ""
+			if value != "" {
+				protocol, err := github.ParseProtocol(value)
+				if err != nil {
+					return err
+				}
+				persistedCfg.Protocol = string(protocol)
+			}
+		case "layout":
+			persistedCfg.Layout = ""
+			if value != "" {
+				if _, err := layout.Parse(value); err != nil {
+					return err
+				}
+				persistedCfg.Layout = value
+			}
+		case "scan_max_depth":
+			persistedCfg.ScanMaxDepth = 0
+			if value != "" {
+				depth, err := strconv.Atoi(value)
+				if err != nil || depth < 1 {
+					return fmt.Errorf("invalid scan max depth: %q", value)
+				}
+				persistedCfg.ScanMaxDepth = depth
+			}
+		case "scan_ignore":
+			persistedCfg.ScanIgnore = nil
+			for _, pattern := range strings.Split(value, ",") {
+				if pattern = strings.TrimSpace(pattern); pattern != "" {
+					persistedCfg.ScanIgnore = append(persistedCfg.ScanIgnore, pattern)
+				}
+			}
+			if _, err := local.NewIgnoreMatcher(persistedCfg.ScanIgnore); err != nil {
+				return err
+			}
+		case "copy_mode":
+			persistedCfg.CopyMode = ""
+			if value != "" {
+				mode, err := local.ParseCopyMode(value)
+				if err != nil {
+					return err
+				}
+				persistedCfg.CopyMode = string(mode)
+			}
+		case "owner_hosts":
+			hosts, err := parseOwnerHosts(value)
+			if err != nil {
+				return err
+			}
+			persistedCfg.OwnerHosts = hosts
 		}
 	}
 
@@ -228,6 +347,14 @@ func (m *SettingsModel) View() string {
 	return settingsOverlayStyle.Render(b.String())
 }
 
+// formatCount formats a persisted count for display, leaving unset values empty.
+func formatCount(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
 // SetSize sets the size for the settings modal.
 func (m *SettingsModel) SetSize(width, height int) {
 	m.width = width
@@ -249,3 +376,38 @@ var (
 					Underline(true).
 					MarginBottom(2)
 )
+
+// formatOwnerHosts formats owner to host mappings as comma-separated
+// owner=host pairs, sorted by owner.
+func formatOwnerHosts(hosts map[string]string) string {
+	owners := make([]string, 0, len(hosts))
+	for owner := range hosts {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	pairs := make([]string, len(owners))
+	for i, owner := range owners {
+		pairs[i] = owner + "=" + hosts[owner]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// parseOwnerHosts parses comma-separated owner=host pairs.
+func parseOwnerHosts(value string) (map[string]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	hosts := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		owner, host, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		owner = strings.TrimSpace(owner)
+		host = strings.TrimSpace(host)
+		if !ok || owner == "" || host == "" {
+			return nil, fmt.Errorf("invalid owner host %q: expected owner=host", pair)
+		}
+		hosts[owner] = host
+	}
+	return hosts, nil
+}
@@ -0,0 +1,287 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/MoshPitCodes/reposync/internal/clone"
+)
+
+// Rows of the sync confirmation form.
+const (
+	confirmRowDepth = iota
+	confirmRowFilter
+	confirmRowSingleBranch
+	confirmRowSparse
+	confirmRowCount
+)
+
+// SyncConfirmModel asks the user to confirm a sync and choose clone options
+// for the batch.
+type SyncConfirmModel struct {
+	// Complex types first
+	depthInput  textinput.Model
+	filterInput textinput.Model
+	sparseInput textinput.Model
+
+	// Error (16 bytes)
+	err error
+
+	// Ints (8 bytes each)
+	count    int
+	selected int
+
+	// Bools (1 byte each)
+	singleBranch bool
+	visible      bool
+}
+
+// NewSyncConfirmModel creates a new sync confirmation dialog.
+func NewSyncConfirmModel() *SyncConfirmModel {
+	depth := textinput.New()
+	depth.Placeholder = "full history"
+	depth.CharLimit = 6
+
+	filter := textinput.New()
+	filter.Placeholder = clone.FilterBlobless
+	filter.CharLimit = 50
+
+	sparse := textinput.New()
+	sparse.Placeholder = "dir1,dir2"
+	sparse.CharLimit = 200
+
+	return &SyncConfirmModel{
+		depthInput:  depth,
+		filterInput: filter,
+		sparseInput: sparse,
+	}
+}
+
+// Show displays the dialog for count repositories, pre-filled with opts.
+func (m *SyncConfirmModel) Show(count int, opts clone.Options) {
+	m.count = count
+	m.err = nil
+	m.visible = true
+	m.singleBranch = opts.SingleBranch
+
+	m.depthInput.SetValue("")
+	if opts.Depth > 0 {
+		m.depthInput.SetValue(strconv.Itoa(opts.Depth))
+	}
+	m.filterInput.SetValue(opts.Filter)
+	m.sparseInput.SetValue(strings.Join(opts.SparsePaths, ","))
+
+	m.focus(confirmRowDepth)
+}
+
+// Hide hides the dialog.
+func (m *SyncConfirmModel) Hide() {
+	m.visible = false
+	m.depthInput.Blur()
+	m.filterInput.Blur()
+	m.sparseInput.Blur()
+}
+
+// IsVisible returns whether the dialog is currently visible.
+func (m *SyncConfirmModel) IsVisible() bool {
+	return m.visible
+}
+
+// focus moves the cursor to row, focusing its text input if it has one.
+func (m *SyncConfirmModel) focus(row int) {
+	m.selected = row
+	m.depthInput.Blur()
+	m.filterInput.Blur()
+	m.sparseInput.Blur()
+
+	if input := m.input(row); input != nil {
+		input.Focus()
+	}
+}
+
+// input returns the text input for row, or nil for toggle rows.
+func (m *SyncConfirmModel) input(row int) *textinput.Model {
+	switch row {
+	case confirmRowDepth:
+		return &m.depthInput
+	case confirmRowFilter:
+		return &m.filterInput
+	case confirmRowSparse:
+		return &m.sparseInput
+	default:
+		return nil
+	}
+}
+
+// options parses the form into clone options.
+func (m *SyncConfirmModel) options() (clone.Options, error) {
+	opts := clone.Options{
+		Filter:       strings.TrimSpace(m.filterInput.Value()),
+		SingleBranch: m.singleBranch,
+		SparsePaths:  clone.ParseSparsePaths(m.sparseInput.Value()),
+	}
+
+	if value := strings.TrimSpace(m.depthInput.Value()); value != "" {
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 1 {
+			return clone.Options{}, fmt.Errorf("invalid depth %q: must be a positive number", value)
+		}
+		opts.Depth = depth
+	}
+
+	return opts, opts.Validate()
+}
+
+// Update handles input for the dialog.
+func (m *SyncConfirmModel) Update(msg tea.Msg) (*SyncConfirmModel, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		m.Hide()
+		return m, func() tea.Msg {
+			return SyncConfirmMsg{Confirmed: false}
+		}
+
+	case "enter":
+		opts, err := m.options()
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.Hide()
+		return m, func() tea.Msg {
+			return SyncConfirmMsg{Confirmed: true, Options: opts}
+		}
+
+	case "up", "shift+tab":
+		if m.selected > 0 {
+			m.focus(m.selected - 1)
+		}
+		return m, nil
+
+	case "down", "tab":
+		if m.selected < confirmRowCount-1 {
+			m.focus(m.selected + 1)
+		}
+		return m, nil
+
+	case " ":
+		if m.selected == confirmRowSingleBranch {
+			m.singleBranch = !m.singleBranch
+			return m, nil
+		}
+	}
+
+	// Update the focused input
+	var cmd tea.Cmd
+	if input := m.input(m.selected); input != nil {
+		*input, cmd = input.Update(msg)
+		m.err = nil
+	}
+	return m, cmd
+}
+
+// View renders the dialog.
+func (m *SyncConfirmModel) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	var b strings.Builder
+
+	noun := "repositories"
+	if m.count == 1 {
+		noun = "repository"
+	}
+	b.WriteString(dialogTitleStyle.Render(fmt.Sprintf("Sync %d %s", m.count, noun)))
+	b.WriteString("\n\n")
+	b.WriteString(helpDescStyle.Render("Clone options for this batch (leave empty for a full clone)"))
+	b.WriteString("\n\n")
+
+	rows := []struct {
+		label string
+		help  string
+	}{
+		{"Depth", "Shallow clone with this many commits"},
+		{"Filter", "Partial clone filter, e.g. blob:none"},
+		{"Single branch", "Only clone the default branch"},
+		{"Sparse paths", "Comma-separated directories to check out"},
+	}
+
+	for i, row := range rows {
+		labelStyle := lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
+		if i == m.selected {
+			labelStyle = labelStyle.Foreground(secondaryColor)
+		}
+		b.WriteString(labelStyle.Render(row.label))
+		b.WriteString("\n")
+
+		var field string
+		if input := m.input(i); input != nil {
+			field = input.View()
+		} else {
+			check := "[ ]"
+			if m.singleBranch {
+				check = "[✓]"
+			}
+			field = check + " " + row.help
+		}
+		if i == m.selected {
+			b.WriteString(focusedInputStyle.Render(field))
+		} else {
+			b.WriteString(inputStyle.Render(field))
+		}
+		b.WriteString("\n")
+
+		if m.input(i) != nil {
+			b.WriteString("  " + helpDescStyle.Render(row.help) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(RenderMetadata("Per-repository overrides from the config file take precedence"))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(
+		"↑/↓", "navigate",
+		"space", "toggle",
+		"enter", "sync",
+		"esc", "cancel",
+	))
+
+	return dialogStyle.Render(b.String())
+}
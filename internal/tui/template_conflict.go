@@ -19,16 +19,25 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/MoshPitCodes/reposync/internal/local"
 )
 
 // TemplateConflictModel manages the conflict resolution dialog.
 type TemplateConflictModel struct {
+	// Complex types first
+	diff viewport.Model
+
 	// Current conflict information
 	filePath       string
 	targetRepoPath string
 	targetRepoName string
+	templateSize   int64
+	localSize      int64
+	hasDiff        bool
 
 	// Is the dialog visible
 	visible bool
@@ -43,6 +52,7 @@ type TemplateConflictModel struct {
 // NewTemplateConflictModel creates a new conflict dialog model.
 func NewTemplateConflictModel() *TemplateConflictModel {
 	return &TemplateConflictModel{
+		diff:    viewport.New(44, 3),
 		visible: false,
 		cursor:  0,
 		width:   50,
@@ -50,10 +60,15 @@ func NewTemplateConflictModel() *TemplateConflictModel {
 }
 
 // Show displays the conflict dialog for a specific file conflict.
-func (m *TemplateConflictModel) Show(filePath, targetRepoPath string) {
-	m.filePath = filePath
-	m.targetRepoPath = targetRepoPath
-	m.targetRepoName = filepath.Base(targetRepoPath)
+func (m *TemplateConflictModel) Show(conflict TemplateConflictMsg) {
+	m.filePath = conflict.FilePath
+	m.targetRepoPath = conflict.TargetRepoPath
+	m.targetRepoName = filepath.Base(conflict.TargetRepoPath)
+	m.templateSize = conflict.TemplateSize
+	m.localSize = conflict.LocalSize
+	m.hasDiff = conflict.Diff != ""
+	m.diff.SetContent(renderDiff(conflict.Diff))
+	m.diff.GotoTop()
 	m.visible = true
 	m.cursor = 0
 }
@@ -68,9 +83,11 @@ func (m *TemplateConflictModel) IsVisible() bool {
 	return m.visible
 }
 
-// SetWidth sets the dialog width.
-func (m *TemplateConflictModel) SetWidth(width int) {
-	m.width = width
+// SetSize fits the dialog and its diff into the given terminal size.
+func (m *TemplateConflictModel) SetSize(width, height int) {
+	m.width = max(min(width-10, 100), 50)
+	m.diff.Width = m.width - 8
+	m.diff.Height = max(height-28, 3)
 }
 
 // Update handles messages for the conflict dialog.
@@ -108,6 +125,14 @@ func (m *TemplateConflictModel) Update(msg tea.Msg) (*TemplateConflictModel, tea
 			}
 			return m, nil
 
+		case "pgup":
+			m.diff.PageUp()
+			return m, nil
+
+		case "pgdown":
+			m.diff.PageDown()
+			return m, nil
+
 		case "o":
 			// Overwrite
 			m.visible = false
@@ -196,8 +221,26 @@ func (m *TemplateConflictModel) View() string {
 	b.WriteString(templateConflictLabelStyle.Render("Target:"))
 	b.WriteString(" ")
 	b.WriteString(templateConflictTargetStyle.Render(m.targetRepoName))
+	b.WriteString("\n")
+
+	b.WriteString(templateConflictLabelStyle.Render("Size:"))
+	b.WriteString(" ")
+	b.WriteString(templateConflictMessageStyle.Render(fmt.Sprintf("template %s • local %s",
+		local.FormatSize(m.templateSize), local.FormatSize(m.localSize))))
 	b.WriteString("\n\n")
 
+	// Diff from the local file to the template
+	if m.hasDiff {
+		header := "Changes"
+		if m.diff.TotalLineCount() > m.diff.Height {
+			header += fmt.Sprintf(" (%d%%, pgup/pgdown to scroll)", int(m.diff.ScrollPercent()*100))
+		}
+		b.WriteString(templateConflictLabelStyle.Render(header))
+		b.WriteString("\n")
+		b.WriteString(m.diff.View())
+		b.WriteString("\n\n")
+	}
+
 	// Message
 	message := "This file already exists in the target repository.\nWhat would you like to do?"
 	b.WriteString(templateConflictMessageStyle.Render(message))
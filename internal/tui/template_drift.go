@@ -0,0 +1,233 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/MoshPitCodes/reposync/internal/template"
+)
+
+// TemplateDriftModel shows how the template files synced into the local
+// repositories drifted from their template since the last sync.
+type TemplateDriftModel struct {
+	// Slices (24 bytes)
+	statuses []template.FileStatus
+
+	// Ints (8 bytes each)
+	cursor  int
+	offset  int // First visible status
+	targets int // Repositories checked
+	width   int
+	height  int
+
+	// Bools (1 byte each)
+	visible bool
+	loading bool
+}
+
+// NewTemplateDriftModel creates a hidden drift view.
+func NewTemplateDriftModel() *TemplateDriftModel {
+	return &TemplateDriftModel{width: 80, height: 24}
+}
+
+// Show displays the view while the drift of targets is checked.
+func (m *TemplateDriftModel) Show(targets int) {
+	m.statuses = nil
+	m.cursor, m.offset = 0, 0
+	m.targets = targets
+	m.visible = true
+	m.loading = true
+}
+
+// SetStatuses shows the checked files.
+func (m *TemplateDriftModel) SetStatuses(statuses []template.FileStatus) {
+	m.statuses = statuses
+	m.loading = false
+}
+
+// Hide hides the view.
+func (m *TemplateDriftModel) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the view is visible.
+func (m *TemplateDriftModel) IsVisible() bool {
+	return m.visible
+}
+
+// IsLoading reports whether the drift is still being checked.
+func (m *TemplateDriftModel) IsLoading() bool {
+	return m.loading
+}
+
+// SetSize fits the view into the given terminal size.
+func (m *TemplateDriftModel) SetSize(width, height int) {
+	m.width = max(min(width-10, 110), 50)
+	m.height = height
+	m.ensureVisible()
+}
+
+// Update handles key messages for the view.
+func (m *TemplateDriftModel) Update(msg tea.Msg) (*TemplateDriftModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.visible {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "d":
+		m.visible = false
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.statuses)-1 {
+			m.cursor++
+		}
+
+	case "pgup":
+		m.cursor = max(m.cursor-m.listRows(), 0)
+
+	case "pgdown":
+		m.cursor = max(min(m.cursor+m.listRows(), len(m.statuses)-1), 0)
+	}
+	m.ensureVisible()
+
+	return m, nil
+}
+
+// listRows returns how many statuses are shown at once.
+func (m *TemplateDriftModel) listRows() int {
+	return max(m.height-16, 3)
+}
+
+// ensureVisible scrolls the list to keep the cursor visible.
+func (m *TemplateDriftModel) ensureVisible() {
+	rows := m.listRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// View renders the drift view.
+func (m *TemplateDriftModel) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(templateTargetsHeaderStyle.Render("🧭 Template Drift"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(templateTargetsHintStyle.Render(fmt.Sprintf("Checking %d repositories against their templates...", m.targets)))
+		return templateDriftStyle.Width(m.width).Render(b.String())
+	case len(m.statuses) == 0:
+		b.WriteString(templateTargetsHintStyle.Render(fmt.Sprintf("None of the %d repositories has a %s yet.", m.targets, template.LockFile)))
+		b.WriteString("\n\n")
+		b.WriteString(templateTargetsHelpStyle.Render("esc close"))
+		return templateDriftStyle.Width(m.width).Render(b.String())
+	}
+
+	counts := template.SummarizeDrift(m.statuses)
+	summary := fmt.Sprintf("%d up to date • %d template changed • %d locally modified • %d both changed",
+		counts[template.DriftUpToDate], counts[template.DriftUpstream], counts[template.DriftLocal], counts[template.DriftBoth])
+	b.WriteString(templateTargetsCountStyle.Render(summary))
+	b.WriteString("\n\n")
+
+	rows := m.listRows()
+	end := min(m.offset+rows, len(m.statuses))
+	for i := m.offset; i < end; i++ {
+		b.WriteString(m.renderStatus(i))
+		b.WriteString("\n")
+	}
+	if len(m.statuses) > rows {
+		b.WriteString(templateTargetsHintStyle.Render(fmt.Sprintf("(%d-%d of %d)", m.offset+1, end, len(m.statuses))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Provenance of the file under the cursor
+	status := m.statuses[m.cursor]
+	if status.Err == nil {
+		source := status.Locked.Source
+		if status.Locked.Ref != "" {
+			source += "@" + status.Locked.Ref
+		}
+		if status.Locked.Commit != "" {
+			source += fmt.Sprintf(" (%.7s)", status.Locked.Commit)
+		}
+		b.WriteString(templateTargetsHintStyle.Render(fmt.Sprintf("Synced from %s on %s",
+			source, status.Locked.SyncedAt.Local().Format("2006-01-02 15:04"))))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(templateTargetsHelpStyle.Render("↑/↓ navigate • pgup/pgdown page • esc close"))
+
+	return templateDriftStyle.Width(m.width).Render(b.String())
+}
+
+// renderStatus renders the list row of the status at index i.
+func (m *TemplateDriftModel) renderStatus(i int) string {
+	status := m.statuses[i]
+
+	state, style := status.State.String(), templateDriftStateStyles[status.State]
+	detail := status.Detail
+	if status.Err != nil {
+		state, style, detail = "error", templateDriftErrorStyle, status.Err.Error()
+	}
+
+	line := fmt.Sprintf("%-16s  %s → %s", state, status.Path, filepath.Base(status.TargetRepo))
+	if detail != "" {
+		line += " (" + detail + ")"
+	}
+
+	if i == m.cursor {
+		return templateTargetsSelectedStyle.Render(line)
+	}
+	return style.Render(line)
+}
+
+// Styles for the drift view
+var (
+	templateDriftStyle = lipgloss.NewStyle().
+				Padding(1, 2).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(primaryColor).
+				Background(bgColor)
+
+	templateDriftStateStyles = map[template.DriftState]lipgloss.Style{
+		template.DriftUpToDate: lipgloss.NewStyle().Foreground(successColor),
+		template.DriftUpstream: lipgloss.NewStyle().Foreground(accentColor),
+		template.DriftLocal:    lipgloss.NewStyle().Foreground(warningColor),
+		template.DriftBoth:     lipgloss.NewStyle().Foreground(errorColor).Bold(true),
+	}
+
+	templateDriftErrorStyle = lipgloss.NewStyle().
+				Foreground(errorColor)
+)
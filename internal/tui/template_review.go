@@ -0,0 +1,389 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/MoshPitCodes/reposync/internal/template"
+)
+
+// reviewChoice is what syncing does with a planned change.
+type reviewChoice int
+
+const (
+	choiceSkip    reviewChoice = iota // Leave the target as it is
+	choiceWrite                       // Write without asking
+	choiceConfirm                     // Ask before overwriting the existing file
+)
+
+// TemplateReviewModel shows the planned template changes with their diffs
+// and lets the user deselect individual changes before syncing.
+type TemplateReviewModel struct {
+	// Complex types first
+	diff viewport.Model
+
+	// Interfaces (16 bytes)
+	err error // Why the plan could not be computed
+
+	// Slices (24 bytes each)
+	changes []template.Change
+	choices []reviewChoice // Parallel to changes
+
+	// Ints (8 bytes each)
+	cursor int
+	offset int // First visible change
+	width  int
+	height int
+
+	// Bools (1 byte each)
+	loading bool
+}
+
+// NewTemplateReviewModel creates an empty review model.
+func NewTemplateReviewModel() *TemplateReviewModel {
+	return &TemplateReviewModel{
+		diff:   viewport.New(0, 0),
+		width:  60,
+		height: 20,
+	}
+}
+
+// SetLoading clears the review while the plan is computed.
+func (m *TemplateReviewModel) SetLoading() {
+	m.changes, m.choices, m.err = nil, nil, nil
+	m.cursor, m.offset = 0, 0
+	m.loading = true
+	m.refreshDiff()
+}
+
+// SetPlan shows the planned changes. Every create and update is selected,
+// and updates ask before overwriting the existing file.
+func (m *TemplateReviewModel) SetPlan(changes []template.Change, err error) {
+	m.loading = false
+	m.changes, m.err = changes, err
+	m.choices = make([]reviewChoice, len(changes))
+	m.selectAll()
+	m.cursor, m.offset = 0, 0
+	m.refreshDiff()
+}
+
+// IsLoading reports whether the plan is still being computed.
+func (m *TemplateReviewModel) IsLoading() bool {
+	return m.loading
+}
+
+// Ready reports whether a plan is shown that can be applied.
+func (m *TemplateReviewModel) Ready() bool {
+	return !m.loading && m.err == nil && m.changes != nil
+}
+
+// SetSize sets the dimensions of the review.
+func (m *TemplateReviewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.diff.Width = max(width-8, 20)
+	m.diff.Height = max(height-m.listRows()-12, 3)
+	m.ensureVisible()
+}
+
+// Changes returns the plan to apply. Deselected changes are turned into
+// skips so they are reported without being written.
+func (m *TemplateReviewModel) Changes() []template.Change {
+	changes := make([]template.Change, len(m.changes))
+	for i, change := range m.changes {
+		if change.Action.IsChange() && m.choices[i] == choiceSkip {
+			change.Action, change.Reason = template.PlanSkip, "deselected"
+		}
+		changes[i] = change
+	}
+	return changes
+}
+
+// Approved returns the destination paths of the updates that may be
+// overwritten without asking again.
+func (m *TemplateReviewModel) Approved() map[string]bool {
+	approved := make(map[string]bool)
+	for i, change := range m.changes {
+		if change.Action == template.PlanUpdate && m.choices[i] == choiceWrite {
+			approved[filepath.Join(change.TargetRepo, change.FilePath)] = true
+		}
+	}
+	return approved
+}
+
+// SelectedCount returns the number of changes that will be written or
+// confirmed during the sync.
+func (m *TemplateReviewModel) SelectedCount() int {
+	return m.countChoices(choiceWrite) + m.countChoices(choiceConfirm)
+}
+
+// countChoices returns the number of changes with the given choice.
+func (m *TemplateReviewModel) countChoices(choice reviewChoice) int {
+	count := 0
+	for i, change := range m.changes {
+		if change.Action.IsChange() && m.choices[i] == choice {
+			count++
+		}
+	}
+	return count
+}
+
+// selectAll selects every change, with updates asking first.
+func (m *TemplateReviewModel) selectAll() {
+	for i, change := range m.changes {
+		switch change.Action {
+		case template.PlanCreate, template.PlanMerge:
+			m.choices[i] = choiceWrite
+		case template.PlanUpdate:
+			m.choices[i] = choiceConfirm
+		default:
+			m.choices[i] = choiceSkip
+		}
+	}
+}
+
+// toggle cycles the choice of the change under the cursor. Creates and
+// merges are written or skipped; updates cycle through ask, overwrite and
+// skip.
+func (m *TemplateReviewModel) toggle() {
+	if m.cursor >= len(m.changes) {
+		return
+	}
+
+	switch m.changes[m.cursor].Action {
+	case template.PlanCreate, template.PlanMerge:
+		if m.choices[m.cursor] == choiceWrite {
+			m.choices[m.cursor] = choiceSkip
+		} else {
+			m.choices[m.cursor] = choiceWrite
+		}
+	case template.PlanUpdate:
+		switch m.choices[m.cursor] {
+		case choiceConfirm:
+			m.choices[m.cursor] = choiceWrite
+		case choiceWrite:
+			m.choices[m.cursor] = choiceSkip
+		default:
+			m.choices[m.cursor] = choiceConfirm
+		}
+	}
+}
+
+// Update handles key messages for the review.
+func (m *TemplateReviewModel) Update(msg tea.Msg) (*TemplateReviewModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.Ready() {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureVisible()
+			m.refreshDiff()
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.changes)-1 {
+			m.cursor++
+			m.ensureVisible()
+			m.refreshDiff()
+		}
+
+	case " ":
+		m.toggle()
+
+	case "a":
+		m.selectAll()
+
+	case "n":
+		for i := range m.choices {
+			m.choices[i] = choiceSkip
+		}
+
+	case "pgup":
+		m.diff.PageUp()
+
+	case "pgdown":
+		m.diff.PageDown()
+	}
+
+	return m, nil
+}
+
+// listRows returns how many changes are shown at once, leaving at least
+// half of the height to the diff.
+func (m *TemplateReviewModel) listRows() int {
+	return max(min(len(m.changes), (m.height-12)/2), 3)
+}
+
+// ensureVisible scrolls the list to keep the cursor visible.
+func (m *TemplateReviewModel) ensureVisible() {
+	rows := m.listRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// refreshDiff shows the diff of the change under the cursor.
+func (m *TemplateReviewModel) refreshDiff() {
+	if m.cursor >= len(m.changes) {
+		m.diff.SetContent("")
+		return
+	}
+
+	change := m.changes[m.cursor]
+	var content string
+	switch {
+	case change.Diff != "":
+		content = renderDiff(change.Diff)
+	case change.Action == template.PlanIdentical:
+		content = templateTargetsHintStyle.Render("The target file already matches the template.")
+	default:
+		content = templateTargetsHintStyle.Render("Not synced: " + change.Reason)
+	}
+	m.diff.SetContent(content)
+	m.diff.GotoTop()
+}
+
+// renderDiff colors the lines of a unified diff.
+func renderDiff(diff string) string {
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = diffHeaderStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = diffHunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = diffAddStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = diffRemoveStyle.Render(line)
+		default:
+			lines[i] = diffContextStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the review.
+func (m *TemplateReviewModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(templateTargetsHeaderStyle.Render("🔍 Review Changes"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(templateTargetsHintStyle.Render("Comparing template files with the targets..."))
+		return templateTargetsStyle.Width(m.width).Render(b.String())
+	case m.err != nil:
+		b.WriteString(RenderError("Failed to plan the sync: " + m.err.Error()))
+		return templateTargetsStyle.Width(m.width).Render(b.String())
+	}
+
+	counts := template.SummarizePlan(m.changes)
+	summary := fmt.Sprintf("Selected: %d/%d changes • %d to confirm • %d identical • %d skipped",
+		m.SelectedCount(), counts[template.PlanCreate]+counts[template.PlanUpdate]+counts[template.PlanMerge],
+		m.countChoices(choiceConfirm), counts[template.PlanIdentical], counts[template.PlanSkip])
+	b.WriteString(templateTargetsCountStyle.Render(summary))
+	b.WriteString("\n\n")
+
+	rows := m.listRows()
+	end := min(m.offset+rows, len(m.changes))
+	for i := m.offset; i < end; i++ {
+		b.WriteString(m.renderChange(i))
+		b.WriteString("\n")
+	}
+	if len(m.changes) > rows {
+		b.WriteString(templateTargetsHintStyle.Render(fmt.Sprintf("(%d-%d of %d)", m.offset+1, end, len(m.changes))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	header := "Diff"
+	if m.diff.TotalLineCount() > m.diff.Height {
+		header += fmt.Sprintf(" (%d%%, pgup/pgdown to scroll)", int(m.diff.ScrollPercent()*100))
+	}
+	b.WriteString(templateTargetsCountStyle.Render(header))
+	b.WriteString("\n")
+	b.WriteString(m.diff.View())
+	b.WriteString("\n\n")
+
+	b.WriteString(templateTargetsHelpStyle.Render("↑/↓ navigate • space toggle ([?] ask, [✓] overwrite) • a all • n none • enter sync selected"))
+
+	return templateTargetsStyle.Width(m.width).Render(b.String())
+}
+
+// renderChange renders the list row of the change at index i.
+func (m *TemplateReviewModel) renderChange(i int) string {
+	change := m.changes[i]
+
+	checkbox := "[ ]"
+	switch {
+	case !change.Action.IsChange():
+		checkbox = "[·]"
+	case m.choices[i] == choiceWrite:
+		checkbox = "[✓]"
+	case m.choices[i] == choiceConfirm:
+		checkbox = "[?]"
+	}
+	line := fmt.Sprintf("%s %-9s %s → %s", checkbox, change.Action, change.FilePath, filepath.Base(change.TargetRepo))
+	if change.Merge != template.MergeNone {
+		line += " (" + change.Merge.String() + ")"
+	}
+
+	var style lipgloss.Style
+	switch {
+	case i == m.cursor:
+		style = templateTargetsSelectedStyle
+	case !change.Action.IsChange():
+		style = templateTargetsDisabledStyle
+	case m.choices[i] != choiceSkip:
+		style = templateTargetsCheckedStyle
+	default:
+		style = templateTargetsItemStyle
+	}
+	return style.Render(line)
+}
+
+// Styles for diffs
+var (
+	diffHeaderStyle = lipgloss.NewStyle().
+			Foreground(fgColor).
+			Bold(true)
+
+	diffHunkStyle = lipgloss.NewStyle().
+			Foreground(secondaryColor)
+
+	diffAddStyle = lipgloss.NewStyle().
+			Foreground(successColor)
+
+	diffRemoveStyle = lipgloss.NewStyle().
+			Foreground(errorColor)
+
+	diffContextStyle = lipgloss.NewStyle().
+				Foreground(mutedColor)
+)
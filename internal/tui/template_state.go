@@ -26,6 +26,8 @@ const (
 	StepBrowseTree
 	// StepSelectTargets is the step where user selects target local repositories.
 	StepSelectTargets
+	// StepReview is the step where user reviews the planned changes and their diffs.
+	StepReview
 	// StepSyncing is the step where the sync operation is in progress.
 	StepSyncing
 	// StepComplete is the step shown after sync completes.
@@ -41,6 +43,8 @@ func (s TemplateWorkflowStep) String() string {
 		return "Browse Files"
 	case StepSelectTargets:
 		return "Select Targets"
+	case StepReview:
+		return "Review Changes"
 	case StepSyncing:
 		return "Syncing"
 	case StepComplete:
@@ -94,9 +98,12 @@ type TemplateSyncState struct {
 	SyncProgress TemplateSyncProgress
 
 	// Sync results (deprecated, use SyncProgress)
-	SyncedCount  int
-	SkippedCount int
-	ErrorCount   int
+	SyncedCount     int
+	UnchangedCount  int
+	SkippedCount    int
+	ErrorCount      int
+	MergedCount     int
+	ConflictedCount int
 }
 
 // NewTemplateSyncState creates a new template sync state initialized to the first step.
@@ -122,8 +129,11 @@ func (s *TemplateSyncState) Reset() {
 	s.OverwriteAll = false
 	s.SkipAll = false
 	s.SyncedCount = 0
+	s.UnchangedCount = 0
 	s.SkippedCount = 0
 	s.ErrorCount = 0
+	s.MergedCount = 0
+	s.ConflictedCount = 0
 }
 
 // SetTemplate sets the template repository information (GitHub).
@@ -71,6 +71,14 @@ func (m Model) renderView() string {
 		view = m.renderWithOverlay(view, m.repoExistsDialog.View())
 	}
 
+	if m.syncConfirm.IsVisible() {
+		view = m.renderWithOverlay(view, m.syncConfirm.View())
+	}
+
+	if m.exec.IsVisible() {
+		view = m.renderWithOverlay(view, m.exec.View())
+	}
+
 	// Template-specific overlays
 	if m.mode == ModeTemplate {
 		// Show template selector as overlay (like settings)
@@ -78,6 +86,11 @@ func (m Model) renderView() string {
 			view = m.renderWithOverlay(view, m.renderTemplateSelectorOverlay())
 		}
 
+		// Show drift view as overlay
+		if m.templateDrift != nil && m.templateDrift.IsVisible() {
+			view = m.renderWithOverlay(view, m.templateDrift.View())
+		}
+
 		// Show conflict dialog as overlay
 		if m.templateConflict != nil && m.templateConflict.IsVisible() {
 			view = m.renderWithOverlay(view, m.templateConflict.View())
@@ -137,7 +150,16 @@ func (m Model) renderOwnerBar() string {
 	totalCount := len(m.list.filtered)
 
 	leftPart := fmt.Sprintf("Owner: %s %s", icon, m.owner)
+	if host := m.config.HostForOwner(m.owner); host != "" {
+		leftPart += fmt.Sprintf(" (%s)", host)
+	}
+	if m.mode == ModePersonal {
+		leftPart += fmt.Sprintf(" • %s • %s", m.personalView, m.visibility)
+	}
 	rightPart := fmt.Sprintf("%d selected / %d", selectedCount, totalCount)
+	if rate, ok := m.cachedClientForOwner(m.owner).RateLimit(); ok {
+		rightPart += fmt.Sprintf(" • API %d/%d", rate.Remaining, rate.Limit)
+	}
 
 	spacer := ""
 	if m.width > 0 {
@@ -207,6 +229,7 @@ func (m Model) renderFooter() string {
 		if m.templateState == nil || m.templateState.Step == StepSelectTemplate {
 			bindings = []string{
 				"s/enter", "select template",
+				"d", "drift",
 				"?", "help",
 				"q", "quit",
 			}
@@ -227,6 +250,16 @@ func (m Model) renderFooter() string {
 				"space", "toggle",
 				"a/n", "all/none",
 				"type", "filter",
+				"enter", "review",
+				"esc", "back",
+				"q", "quit",
+			}
+		} else if m.templateState.Step == StepReview {
+			bindings = []string{
+				"↑/↓", "navigate",
+				"space", "toggle",
+				"a/n", "all/none",
+				"pgup/pgdn", "scroll diff",
 				"enter", "sync",
 				"esc", "back",
 				"q", "quit",
@@ -250,6 +283,22 @@ func (m Model) renderFooter() string {
 			"/", "search",
 			"s", "sort",
 			"enter", "sync",
+			"p", "pull",
+			"x", "exec",
+			"?", "help",
+			"q", "quit",
+		}
+	} else if m.mode == ModePersonal {
+		bindings = []string{
+			"↑/↓", "navigate",
+			"space", "toggle",
+			"a/n", "all/none",
+			"/", "search",
+			"s", "sort",
+			"f/v", "affiliation/visibility",
+			"o", "owner",
+			"enter", "sync",
+			"p", "pull",
 			"?", "help",
 			"q", "quit",
 		}
@@ -262,6 +311,7 @@ func (m Model) renderFooter() string {
 			"s", "sort",
 			"o", "owner",
 			"enter", "sync",
+			"p", "pull",
 			"?", "help",
 			"q", "quit",
 		}
@@ -303,6 +353,7 @@ func (m Model) renderHelpOverlay() string {
 			"enter", "Open template selector",
 			"ctrl+t", "Toggle GitHub/Local source",
 			"↑/↓", "Navigate recent templates",
+			"d", "Show template drift of local repos",
 		}
 
 		sections["Tree Browser"] = []string{
@@ -322,11 +373,21 @@ func (m Model) renderHelpOverlay() string {
 			"n", "Deselect all",
 		}
 
+		sections["Review Changes"] = []string{
+			"↑/↓", "Navigate changes",
+			"space", "Toggle change (updates: ask, overwrite, skip)",
+			"a", "Select all changes",
+			"n", "Deselect all",
+			"pgup/pgdn", "Scroll diff",
+			"enter", "Sync selected changes",
+		}
+
 		sections["Conflict Resolution"] = []string{
 			"o", "Overwrite file",
 			"s", "Skip file",
 			"O", "Overwrite all",
 			"S", "Skip all",
+			"pgup/pgdn", "Scroll diff",
 		}
 	} else {
 		// List navigation
@@ -349,6 +410,13 @@ func (m Model) renderHelpOverlay() string {
 			"/", "Search/filter",
 			"s", "Cycle sort mode",
 			"enter", "Start sync",
+			"p", "Pull selected (fetch, fast-forward only)",
+		}
+
+		if m.mode == ModeLocal {
+			sections["Actions"] = append(sections["Actions"],
+				"x", "Run a command in selected",
+			)
 		}
 
 		if m.mode != ModeLocal {
@@ -356,6 +424,13 @@ func (m Model) renderHelpOverlay() string {
 				"o", "Change owner",
 			}
 		}
+
+		if m.mode == ModePersonal {
+			sections["GitHub"] = append(sections["GitHub"],
+				"f", "Cycle all/owned/collaborator repos",
+				"v", "Cycle all/public/private repos",
+			)
+		}
 	}
 
 	return RenderHelpOverlay(sections)
@@ -403,6 +478,8 @@ func (m Model) renderTemplateWorkflow() string {
 		return m.renderTemplateTree()
 	case StepSelectTargets:
 		return m.renderTemplateTargets()
+	case StepReview:
+		return m.renderTemplateReview()
 	case StepSyncing:
 		return m.renderTemplateSyncProgress()
 	case StepComplete:
@@ -436,7 +513,8 @@ func (m Model) renderTemplateWelcome() string {
 		"1. Select a template repository (GitHub or Local)",
 		"2. Browse and select files to sync",
 		"3. Choose target repositories",
-		"4. Sync files to targets",
+		"4. Review the changes and their diffs",
+		"5. Sync files to targets",
 	}
 
 	for _, step := range steps {
@@ -450,7 +528,7 @@ func (m Model) renderTemplateWelcome() string {
 	hint := lipgloss.NewStyle().
 		Foreground(accentColor).
 		Bold(true).
-		Render("Press 's' or Enter to select a template...")
+		Render("Press 's' or Enter to select a template, or 'd' to check synced repositories for drift...")
 
 	b.WriteString(hint)
 	b.WriteString("\n")
@@ -543,6 +621,16 @@ func (m Model) renderTemplateTargets() string {
 	return m.templateTargets.View()
 }
 
+// renderTemplateReview renders the review of the planned changes.
+func (m Model) renderTemplateReview() string {
+	// Same chrome as the target selector
+	reviewHeight := max(m.height-12, 10)
+	reviewWidth := max(m.width-8, 40)
+	m.templateReview.SetSize(reviewWidth, reviewHeight)
+
+	return m.templateReview.View()
+}
+
 // renderTemplateSyncProgress renders the template sync progress.
 func (m Model) renderTemplateSyncProgress() string {
 	var b strings.Builder
@@ -592,6 +680,14 @@ func (m Model) renderTemplateSyncProgress() string {
 			m.templateState.SyncProgress.Current,
 			m.templateState.SyncProgress.Total)
 		b.WriteString(lipgloss.NewStyle().Foreground(mutedColor).Render(stats))
+
+		// GitHub templates fetch every file through the API
+		if !m.templateState.IsLocal {
+			if rate, ok := m.cachedClientForOwner(m.templateState.TemplateOwner).RateLimit(); ok {
+				quota := fmt.Sprintf("\nGitHub API quota: %s", rate)
+				b.WriteString(lipgloss.NewStyle().Foreground(mutedColor).Render(quota))
+			}
+		}
 	}
 
 	style := lipgloss.NewStyle().
@@ -620,6 +716,7 @@ func (m Model) renderTemplateSyncComplete() string {
 	if m.templateState != nil {
 		// Use the deprecated fields which are actually populated
 		synced := m.templateState.SyncedCount
+		unchanged := m.templateState.UnchangedCount
 		skipped := m.templateState.SkippedCount
 		errors := m.templateState.ErrorCount
 
@@ -630,6 +727,24 @@ func (m Model) renderTemplateSyncComplete() string {
 			b.WriteString("\n")
 		}
 
+		if merged := m.templateState.MergedCount; merged > 0 {
+			mergedStr := fmt.Sprintf("⇄ %d of them merged with local changes", merged)
+			b.WriteString(lipgloss.NewStyle().Foreground(successColor).Render(mergedStr))
+			b.WriteString("\n")
+		}
+
+		if conflicted := m.templateState.ConflictedCount; conflicted > 0 {
+			conflictedStr := fmt.Sprintf("⚠ %d merged with conflict markers to resolve", conflicted)
+			b.WriteString(lipgloss.NewStyle().Foreground(warningColor).Render(conflictedStr))
+			b.WriteString("\n")
+		}
+
+		if unchanged > 0 {
+			unchangedStr := fmt.Sprintf("= %d files already up to date", unchanged)
+			b.WriteString(lipgloss.NewStyle().Foreground(mutedColor).Render(unchangedStr))
+			b.WriteString("\n")
+		}
+
 		if skipped > 0 {
 			skippedStr := fmt.Sprintf("○ %d files skipped", skipped)
 			b.WriteString(lipgloss.NewStyle().Foreground(warningColor).Render(skippedStr))
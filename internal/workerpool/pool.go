@@ -0,0 +1,101 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package workerpool provides a bounded worker pool for running repository
+// operations concurrently.
+package workerpool
+
+import "sync"
+
+// Status represents the state of a single task reported through an Event.
+type Status int
+
+const (
+	// StatusStarted is reported when a worker picks up a task.
+	StatusStarted Status = iota
+	// StatusSucceeded is reported when a task finishes without error.
+	StatusSucceeded
+	// StatusFailed is reported when a task finishes with an error.
+	StatusFailed
+)
+
+// String returns the string representation of the status.
+func (s Status) String() string {
+	switch s {
+	case StatusStarted:
+		return "started"
+	case StatusSucceeded:
+		return "succeeded"
+	case StatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event reports progress for a single task.
+type Event struct {
+	Index  int
+	Name   string
+	Status Status
+	Err    error
+	Detail string // What a successful task did, when worth reporting
+}
+
+// Started returns a StatusStarted event for the task.
+func Started(index int, name string) Event {
+	return Event{Index: index, Name: name, Status: StatusStarted}
+}
+
+// Finished returns a StatusSucceeded or StatusFailed event depending on err.
+func Finished(index int, name string, err error) Event {
+	if err != nil {
+		return Event{Index: index, Name: name, Status: StatusFailed, Err: err}
+	}
+	return Event{Index: index, Name: name, Status: StatusSucceeded}
+}
+
+// Run calls fn for every index in [0, n) using at most jobs goroutines and
+// blocks until all calls have returned. A jobs value below 1 runs tasks serially.
+func Run(jobs, n int, fn func(i int)) {
+	if n <= 0 {
+		return
+	}
+	if jobs < 1 {
+		jobs = 1
+	}
+	if jobs > n {
+		jobs = n
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	wg.Wait()
+}
@@ -0,0 +1,80 @@
+// Copyright 2024-2025 MoshPitCodes
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workerpool
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		jobs int
+		n    int
+	}{
+		{name: "serial when jobs is zero", jobs: 0, n: 5},
+		{name: "more jobs than tasks", jobs: 10, n: 3},
+		{name: "bounded concurrency", jobs: 3, n: 20},
+		{name: "no tasks", jobs: 4, n: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, peak int32
+			var mu sync.Mutex
+			seen := make(map[int]bool)
+
+			Run(tt.jobs, tt.n, func(i int) {
+				cur := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+
+				mu.Lock()
+				seen[i] = true
+				mu.Unlock()
+			})
+
+			assert.Len(t, seen, tt.n, "every task should run exactly once")
+
+			limit := tt.jobs
+			if limit < 1 {
+				limit = 1
+			}
+			assert.LessOrEqual(t, int(peak), limit, "concurrency exceeded jobs")
+		})
+	}
+}
+
+func TestFinished(t *testing.T) {
+	ok := Finished(1, "repo", nil)
+	assert.Equal(t, StatusSucceeded, ok.Status)
+	assert.NoError(t, ok.Err)
+
+	failed := Finished(2, "repo", errors.New("boom"))
+	assert.Equal(t, StatusFailed, failed.Status)
+	assert.EqualError(t, failed.Err, "boom")
+}